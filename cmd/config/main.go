@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"go.risoftinc.com/xarch/config"
+)
+
+const appName = "go run cmd/config/main.go"
+
+func main() {
+	format := flag.String("format", config.FormatYAML, "output format of the print command: json or yaml")
+	flag.Usage = usage
+	flag.Parse()
+
+	// Load configuration without stopping on validation errors, so a broken setup can be inspected
+	cfg, err := config.Load()
+	if err != nil {
+		log.Printf("Configuration has problems: %v", err)
+	}
+
+	switch flag.Arg(0) {
+	case "print":
+		printConfig(cfg, *format)
+	case "sources":
+		printSources()
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+// printConfig prints the effective configuration with secrets masked
+func printConfig(cfg config.Config, format string) {
+	out, err := cfg.Dump(format)
+	if err != nil {
+		log.Fatalf("Failed to print configuration: %v", err)
+	}
+	os.Stdout.Write(out)
+	if format == config.FormatJSON {
+		fmt.Println()
+	}
+}
+
+// printSources prints which layer supplied every configuration key
+func printSources() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tSOURCE")
+	for _, source := range config.Sources() {
+		fmt.Fprintf(w, "%s\t%s\n", source.Key, source.Source)
+	}
+	w.Flush()
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s [-format=json|yaml] <command>\n\n", appName)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	fmt.Fprintln(os.Stderr, "  print     print the effective configuration with secrets masked")
+	fmt.Fprintln(os.Stderr, "  sources   print which layer supplied each configuration key")
+}
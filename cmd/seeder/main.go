@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"go.risoftinc.com/goseeder"
@@ -13,8 +14,14 @@ func main() {
 	// Load configuration
 	cfg := config.Configuration()
 
-	// Connect to database using existing driver
-	db := driver.ConnectDB(cfg.Database)
+	// Connect to database using existing driver, seeders read what they just wrote so replicas are not used
+	// and they need the database right away
+	cfg.Database.Replicas = nil
+	cfg.Connect.StartDegraded = false
+	db, _, err := driver.ConnectDB(context.Background(), cfg.Database, cfg.Connect)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
 
 	// Create seeder manager
 	manager := goseeder.NewSeederManager()
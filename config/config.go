@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -17,29 +18,54 @@ type (
 		Redis           RedisConfig
 		Logger          LoggerConfig
 		ResponseManager ResponseManager
+		Lifecycle       LifecycleConfig
+		Connect         ConnectConfig
+		Migrate         MigrateConfig
+		Admin           AdminConfig
+		Health          HealthConfig
+		Metrics         MetricsConfig
+		Tracing         TracingConfig
+		AccessLog       AccessLogConfig
+		Auth            AuthConfig
+		RBAC            RBACConfig
+		Pagination      PaginationConfig
 	}
 
 	HttpServer struct {
-		Server string
-		Port   int
-		URL    string
+		Server      string
+		Port        int
+		URL         string
+		UsingSecure bool
+		TLS         TLSConfig
 	}
 
 	GrpcServer struct {
-		Server string
-		Port   int
-		URL    string
+		Server      string
+		Port        int
+		URL         string
+		UsingSecure bool
+		TLS         TLSConfig
+		// HealthCheckInterval is how often grpc.health.v1 statuses are refreshed
+		HealthCheckInterval time.Duration
 	}
 
 	DatabaseConfig struct {
-		Type          string // "postgres", "mysql", "sqlite"
+		Type          string // "postgres", "mysql", "sqlite", "sqlserver", "cockroach"
 		PostgresDB    PostgresDB
 		MySQLDB       MySQLDB
 		SQLiteDB      SQLiteDB
+		SQLServerDB   SQLServerDB
+		CockroachDB   CockroachDB
 		DBMaxIdleCon  int
 		DBMaxOpenCon  int
 		DBMaxLifeTime int
-		DBDebug       bool
+		DBDebug       bool // logs every statement at debug level
+		// DBSlowThreshold logs statements that take longer at warn level, zero disables it
+		DBSlowThreshold time.Duration
+		DBLogParams     bool // logs statement parameters, they are left out by default
+		// Replicas are DSNs of read replicas in the driver format of Type, reads go to them and writes to the primary
+		Replicas      []string
+		ReplicaPolicy string // "random", "round-robin"
 	}
 
 	PostgresDB struct {
@@ -68,7 +94,32 @@ type (
 		DBPath string
 	}
 
+	SQLServerDB struct {
+		DBUser   string
+		DBPass   string
+		DBServer string
+		DBPort   int
+		DBName   string
+		Encrypt  string // "disable", "false", "true", "strict"
+		// TrustServerCertificate skips the verification of the server certificate, for local development only
+		TrustServerCertificate bool
+	}
+
+	// CockroachDB speaks the PostgreSQL protocol, so it can point at a local PostgreSQL during development
+	CockroachDB struct {
+		DBUser   string
+		DBPass   string
+		DBServer string
+		DBPort   int
+		DBName   string
+		SSLMode  string
+		// Cluster is the routing ID of a CockroachDB Cloud serverless cluster, empty for other deployments
+		Cluster string
+	}
+
 	MongoDBConfig struct {
+		// Enabled connects MongoDB at startup, its settings are only validated when it is enabled
+		Enabled     bool
 		URI         string
 		Username    string
 		Password    string
@@ -80,6 +131,8 @@ type (
 	}
 
 	RedisConfig struct {
+		// Enabled connects Redis at startup, its settings are only validated when it is enabled
+		Enabled      bool
 		Host         string
 		Port         int
 		Username     string
@@ -105,13 +158,143 @@ type (
 		Path     string
 		Interval time.Duration
 	}
+
+	LifecycleConfig struct {
+		StartTimeout    time.Duration
+		ShutdownTimeout time.Duration
+		DrainDelay      time.Duration
+	}
+
+	// ConnectConfig is the retry policy of the database, Redis and MongoDB connections at startup
+	ConnectConfig struct {
+		// MaxAttempts bounds the connection attempts, 0 retries until Timeout
+		MaxAttempts int
+		// Timeout is the deadline for all attempts together, 0 has none
+		Timeout        time.Duration
+		InitialBackoff time.Duration
+		MaxBackoff     time.Duration
+		// StartDegraded starts the servers without waiting, readiness fails while the connection is retried in the background
+		StartDegraded bool
+	}
+
+	MigrateConfig struct {
+		// OnStartup applies the pending migrations before the servers start
+		OnStartup bool
+		// Sets are the migration folders of DB_TYPE applied together in version order, e.g. ddl and dml
+		Sets []string
+		// Table records the applied migrations, the lock of the migration run is named after it
+		Table       string
+		LockTimeout time.Duration
+	}
+
+	TLSConfig struct {
+		CertFile       string
+		KeyFile        string
+		ClientCAFile   string
+		ClientAuth     string // "none", "request", "require", "verify-if-given", "require-and-verify"
+		MinVersion     string // "1.2", "1.3"
+		ReloadInterval time.Duration
+	}
+
+	HealthConfig struct {
+		CheckTimeout time.Duration
+		CacheTTL     time.Duration
+	}
+
+	MetricsConfig struct {
+		Enabled   bool
+		Namespace string
+	}
+
+	TracingConfig struct {
+		Exporter    string // "none", "stdout", "otlp"
+		Endpoint    string
+		Insecure    bool
+		ServiceName string
+		SampleRatio float64
+	}
+
+	AccessLogConfig struct {
+		Enabled       bool
+		SampleRatio   float64
+		SkipPaths     []string
+		LogHeaders    bool
+		LogBody       bool
+		BodyMaxBytes  int
+		RedactHeaders []string
+		RedactFields  []string
+	}
+
+	// AuthConfig configures the JWT verifier, tokens are signed with JWT_SECRET (HS256)
+	// or with the keys of the JWT_JWKS_FILE (HS256, RS256, EdDSA) selected by key ID
+	AuthConfig struct {
+		Secret             string
+		JWKSFile           string
+		JWKSReloadInterval time.Duration
+		Issuer             string
+		Audience           string
+		ClockSkew          time.Duration
+		// SigningKeyID selects the JWKS key that signs issued tokens, empty uses JWT_SECRET or the only key
+		SigningKeyID    string
+		AccessTokenTTL  time.Duration
+		RefreshTokenTTL time.Duration
+		// DenylistStore keeps revoked access token IDs in the database or in Redis
+		DenylistStore string
+		// PublicMethods are gRPC FullMethods callable without a token, health and reflection always are
+		PublicMethods []string
+	}
+
+	// RBACConfig maps the roles of the users.roles column to permissions,
+	// routes and RPCs declare the roles or permissions they require
+	RBACConfig struct {
+		RolePermissions map[string][]string
+	}
+
+	// PaginationConfig configures cursor pagination, cursors are signed so clients cannot forge them
+	PaginationConfig struct {
+		// CursorSecret signs the cursors, empty uses a random key so cursors only work on the instance that issued them
+		CursorSecret string
+	}
+
+	AdminConfig struct {
+		Enabled bool
+		Token   string
+	}
 )
 
+// Configuration loads the configuration and stops the application with a readable report
+// when any value is invalid
 func Configuration() Config {
+	cfg, err := Load()
+	if err != nil {
+		log.Fatalf("Refusing to start: %v", err)
+	}
+
+	log.Println("Success for load all configuration")
+
+	return cfg
+}
+
+// Load reads the configuration from every layer and validates it. The configuration is
+// returned even when validation fails so callers can still inspect it.
+//
+// Precedence from lowest to highest:
+//  1. defaults in the loaders below
+//  2. base file (CONFIG_FILE, default config.yaml)
+//  3. profile overlay (config.<APP_ENV>.yaml)
+//  4. environment variables, including the .env file
+//  5. secrets read from the file named by <KEY>_FILE, e.g. DB_PASS_FILE=/run/secrets/db
+func Load() (Config, error) {
 	if err := env.LoadEnv(".env"); err != nil {
 		log.Println("error read .env file %w", err.Error())
 	}
 
+	layers, err := loadLayers()
+	if err != nil {
+		return Config{}, err
+	}
+	sources.reset(layers)
+
 	cfg := Config{
 		Http:            loadHttpServer(),
 		Grpc:            loadGrpcServer(),
@@ -120,24 +303,36 @@ func Configuration() Config {
 		Redis:           loadRedisConfig(),
 		Logger:          loadLoggerConfig(),
 		ResponseManager: loadResponseManagerConfig(),
+		Lifecycle:       loadLifecycleConfig(),
+		Connect:         loadConnectConfig(),
+		Migrate:         loadMigrateConfig(),
+		Admin:           loadAdminConfig(),
+		Health:          loadHealthConfig(),
+		Metrics:         loadMetricsConfig(),
+		Tracing:         loadTracingConfig(),
+		AccessLog:       loadAccessLogConfig(),
+		Auth:            loadAuthConfig(),
+		RBAC:            loadRBACConfig(),
+		Pagination:      loadPaginationConfig(),
 	}
 
-	log.Println("Success for load all configuration")
-
-	return cfg
+	return cfg, errors.Join(errors.Join(sources.errs...), cfg.Validate())
 }
 
 func loadHttpServer() HttpServer {
 	var cfg HttpServer
 
-	cfg.Server = env.GetEnv("SERVER", "localhost")
-	cfg.Port = env.GetEnv("PORT", 9000)
-	if env.GetEnv("USING_SECURE", true) {
+	cfg.Server = getEnv("SERVER", "localhost")
+	cfg.Port = getEnv("PORT", 9000)
+	cfg.UsingSecure = getEnv("USING_SECURE", false)
+	if cfg.UsingSecure {
 		cfg.URL = "https://" + cfg.Server
 	} else {
 		cfg.URL = "http://" + cfg.Server
 	}
 
+	cfg.TLS = loadTLSConfig("TLS_")
+
 	if cfg.Port != 0 {
 		cfg.URL += fmt.Sprintf(":%d", cfg.Port)
 	}
@@ -149,87 +344,229 @@ func loadHttpServer() HttpServer {
 func loadGrpcServer() GrpcServer {
 	var cfg GrpcServer
 
-	cfg.Server = env.GetEnv("GRPC_SERVER", "localhost")
-	cfg.Port = env.GetEnv("GRPC_PORT", 9001)
+	cfg.Server = getEnv("GRPC_SERVER", "localhost")
+	cfg.Port = getEnv("GRPC_PORT", 9001)
 	cfg.URL = fmt.Sprintf("%s:%d", cfg.Server, cfg.Port)
+	cfg.UsingSecure = getEnv("GRPC_USING_SECURE", false)
+	cfg.TLS = loadTLSConfig("GRPC_TLS_")
+	cfg.HealthCheckInterval = getEnv("GRPC_HEALTH_CHECK_INTERVAL", 10*time.Second)
 
 	return cfg
 }
 
+// loadTLSConfig reads TLS settings whose environment keys start with prefix, e.g. TLS_ or GRPC_TLS_
+func loadTLSConfig(prefix string) TLSConfig {
+	return TLSConfig{
+		CertFile:       getEnv(prefix+"CERT_FILE", ""),
+		KeyFile:        getEnv(prefix+"KEY_FILE", ""),
+		ClientCAFile:   getEnv(prefix+"CLIENT_CA_FILE", ""),
+		ClientAuth:     getEnv(prefix+"CLIENT_AUTH", "none"),
+		MinVersion:     getEnv(prefix+"MIN_VERSION", "1.2"),
+		ReloadInterval: getEnv(prefix+"RELOAD_INTERVAL", time.Minute), // how often certificate files are checked for changes
+	}
+}
+
 func loadDatabaseConfig() DatabaseConfig {
 	return DatabaseConfig{
-		Type: env.GetEnv("DB_TYPE", "postgres"),
+		Type: getEnv("DB_TYPE", "postgres"),
 		PostgresDB: PostgresDB{
-			DBUser:     env.GetEnv("DB_USER", "root"),
-			DBPass:     env.GetEnv("DB_PASS", ""),
-			DBServer:   env.GetEnv("DB_SERVER", "localhost"),
-			DBPort:     env.GetEnv("DB_PORT", 5432),
-			DBName:     env.GetEnv("DB_NAME", "public"),
-			DBTimeZone: env.GetEnv("DB_TIME_ZONE", "Asia/Jakarta"),
-			SSLMode:    env.GetEnv("DB_SSL_MODE", "disable"),
+			DBUser:     getEnv("DB_USER", "root"),
+			DBPass:     getEnv("DB_PASS", ""),
+			DBServer:   getEnv("DB_SERVER", "localhost"),
+			DBPort:     getEnv("DB_PORT", 5432),
+			DBName:     getEnv("DB_NAME", "public"),
+			DBTimeZone: getEnv("DB_TIME_ZONE", "Asia/Jakarta"),
+			SSLMode:    getEnv("DB_SSL_MODE", "disable"),
 		},
 		MySQLDB: MySQLDB{
-			DBUser:     env.GetEnv("DB_USER", "root"),
-			DBPass:     env.GetEnv("DB_PASS", ""),
-			DBServer:   env.GetEnv("DB_SERVER", "localhost"),
-			DBPort:     env.GetEnv("DB_PORT", 3306),
-			DBName:     env.GetEnv("DB_NAME", "public"),
-			DBTimeZone: env.GetEnv("DB_TIME_ZONE", "Asia/Jakarta"),
-			Charset:    env.GetEnv("DB_CHARSET", "utf8mb4"),
-			ParseTime:  env.GetEnv("DB_PARSE_TIME", true),
-			Loc:        env.GetEnv("DB_LOC", "Local"),
+			DBUser:     getEnv("DB_USER", "root"),
+			DBPass:     getEnv("DB_PASS", ""),
+			DBServer:   getEnv("DB_SERVER", "localhost"),
+			DBPort:     getEnv("DB_PORT", 3306),
+			DBName:     getEnv("DB_NAME", "public"),
+			DBTimeZone: getEnv("DB_TIME_ZONE", "Asia/Jakarta"),
+			Charset:    getEnv("DB_CHARSET", "utf8mb4"),
+			ParseTime:  getEnv("DB_PARSE_TIME", true),
+			Loc:        getEnv("DB_LOC", "Local"),
 		},
 		SQLiteDB: SQLiteDB{
-			DBPath: env.GetEnv("DB_PATH", "database.db"),
+			DBPath: getEnv("DB_PATH", "database.db"),
+		},
+		SQLServerDB: SQLServerDB{
+			DBUser:                 getEnv("DB_USER", "sa"),
+			DBPass:                 getEnv("DB_PASS", ""),
+			DBServer:               getEnv("DB_SERVER", "localhost"),
+			DBPort:                 getEnv("DB_PORT", 1433),
+			DBName:                 getEnv("DB_NAME", "xarch"),
+			Encrypt:                getEnv("DB_ENCRYPT", "true"),
+			TrustServerCertificate: getEnv("DB_TRUST_SERVER_CERTIFICATE", false),
 		},
-		DBMaxIdleCon:  env.GetEnv("DB_MAX_IDLE_CON", 10),
-		DBMaxOpenCon:  env.GetEnv("DB_MAX_OPEN_CON", 100),
-		DBMaxLifeTime: env.GetEnv("DB_MAX_LIFE_TIME", 10),
-		DBDebug:       env.GetEnv("DB_DEBUG", false),
+		CockroachDB: CockroachDB{
+			DBUser:   getEnv("DB_USER", "root"),
+			DBPass:   getEnv("DB_PASS", ""),
+			DBServer: getEnv("DB_SERVER", "localhost"),
+			DBPort:   getEnv("DB_PORT", 26257),
+			DBName:   getEnv("DB_NAME", "defaultdb"),
+			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
+			Cluster:  getEnv("DB_CLUSTER", ""),
+		},
+		DBMaxIdleCon:    getEnv("DB_MAX_IDLE_CON", 10),
+		DBMaxOpenCon:    getEnv("DB_MAX_OPEN_CON", 100),
+		DBMaxLifeTime:   getEnv("DB_MAX_LIFE_TIME", 10),
+		DBDebug:         getEnv("DB_DEBUG", false),
+		DBSlowThreshold: getEnv("DB_SLOW_QUERY_THRESHOLD", 200*time.Millisecond),
+		DBLogParams:     getEnv("DB_LOG_PARAMETERS", false),
+		Replicas:        getEnvList("DB_REPLICA_DSNS", nil),
+		ReplicaPolicy:   getEnv("DB_REPLICA_POLICY", "random"),
 	}
 }
 
 func loadLoggerConfig() LoggerConfig {
 	return LoggerConfig{
-		OutputMode: env.GetEnv("LOG_OUTPUT_MODE", "both"), // "terminal", "file", "both"
-		LogLevel:   env.GetEnv("LOG_LEVEL", "debug"),      // "debug", "info", "warn", "error"
-		LogDir:     env.GetEnv("LOG_DIR", "logger"),       // directory for log files
+		OutputMode: getEnv("LOG_OUTPUT_MODE", "both"), // "terminal", "file", "both"
+		LogLevel:   getEnv("LOG_LEVEL", "debug"),      // "debug", "info", "warn", "error"
+		LogDir:     getEnv("LOG_DIR", "logger"),       // directory for log files
 	}
 }
 func loadMongoDBConfig() MongoDBConfig {
 	return MongoDBConfig{
-		URI:         env.GetEnv("MONGODB_URI", "mongodb://localhost:27017"),
-		Username:    env.GetEnv("MONGODB_USERNAME", ""),
-		Password:    env.GetEnv("MONGODB_PASSWORD", ""),
-		Database:    env.GetEnv("MONGODB_DATABASE", "xarch"),
-		MaxPoolSize: env.GetEnv("MONGODB_MAX_POOL_SIZE", uint64(100)),
-		MinPoolSize: env.GetEnv("MONGODB_MIN_POOL_SIZE", uint64(5)),
-		MaxIdleTime: env.GetEnv("MONGODB_MAX_IDLE_TIME", 30*time.Minute),
-		Timeout:     env.GetEnv("MONGODB_TIMEOUT", 10*time.Second),
+		Enabled:     getEnv("MONGODB_ENABLED", false),
+		URI:         getEnv("MONGODB_URI", "mongodb://localhost:27017"),
+		Username:    getEnv("MONGODB_USERNAME", ""),
+		Password:    getEnv("MONGODB_PASSWORD", ""),
+		Database:    getEnv("MONGODB_DATABASE", "xarch"),
+		MaxPoolSize: getEnv("MONGODB_MAX_POOL_SIZE", uint64(100)),
+		MinPoolSize: getEnv("MONGODB_MIN_POOL_SIZE", uint64(5)),
+		MaxIdleTime: getEnv("MONGODB_MAX_IDLE_TIME", 30*time.Minute),
+		Timeout:     getEnv("MONGODB_TIMEOUT", 10*time.Second),
 	}
 }
 
 func loadRedisConfig() RedisConfig {
 	return RedisConfig{
-		Host:         env.GetEnv("REDIS_HOST", "localhost"),
-		Port:         env.GetEnv("REDIS_PORT", 6379),
-		Username:     env.GetEnv("REDIS_USERNAME", "root"),
-		Password:     env.GetEnv("REDIS_PASSWORD", ""),
-		DB:           env.GetEnv("REDIS_DB", 0),
-		MaxRetries:   env.GetEnv("REDIS_MAX_RETRIES", 3),
-		PoolSize:     env.GetEnv("REDIS_POOL_SIZE", 10),
-		MinIdleConns: env.GetEnv("REDIS_MIN_IDLE_CONNS", 5),
-		DialTimeout:  env.GetEnv("REDIS_DIAL_TIMEOUT", 5*time.Second),
-		ReadTimeout:  env.GetEnv("REDIS_READ_TIMEOUT", 3*time.Second),
-		WriteTimeout: env.GetEnv("REDIS_WRITE_TIMEOUT", 3*time.Second),
-		IdleTimeout:  env.GetEnv("REDIS_IDLE_TIMEOUT", 5*time.Minute),
+		Enabled:      getEnv("REDIS_ENABLED", false),
+		Host:         getEnv("REDIS_HOST", "localhost"),
+		Port:         getEnv("REDIS_PORT", 6379),
+		Username:     getEnv("REDIS_USERNAME", "root"),
+		Password:     getEnv("REDIS_PASSWORD", ""),
+		DB:           getEnv("REDIS_DB", 0),
+		MaxRetries:   getEnv("REDIS_MAX_RETRIES", 3),
+		PoolSize:     getEnv("REDIS_POOL_SIZE", 10),
+		MinIdleConns: getEnv("REDIS_MIN_IDLE_CONNS", 5),
+		DialTimeout:  getEnv("REDIS_DIAL_TIMEOUT", 5*time.Second),
+		ReadTimeout:  getEnv("REDIS_READ_TIMEOUT", 3*time.Second),
+		WriteTimeout: getEnv("REDIS_WRITE_TIMEOUT", 3*time.Second),
+		IdleTimeout:  getEnv("REDIS_IDLE_TIMEOUT", 5*time.Minute),
 	}
 }
 
 func loadResponseManagerConfig() ResponseManager {
 	return ResponseManager{
-		Method:   env.GetEnv("RESPONSE_MANAGER_METHOD", "file"),             // "file", "http"
-		Path:     env.GetEnv("RESPONSE_MANAGER_PATH", "config/config.json"), // path to the response manager config file
-		Interval: env.GetEnv("RESPONSE_MANAGER_INTERVAL", 5*time.Minute),    // interval in duration
+		Method:   getEnv("RESPONSE_MANAGER_METHOD", "file"),             // "file", "http"
+		Path:     getEnv("RESPONSE_MANAGER_PATH", "config/config.json"), // path to the response manager config file
+		Interval: getEnv("RESPONSE_MANAGER_INTERVAL", 5*time.Minute),    // interval in duration
+	}
+}
+
+func loadHealthConfig() HealthConfig {
+	return HealthConfig{
+		CheckTimeout: getEnv("HEALTH_CHECK_TIMEOUT", 2*time.Second), // default timeout of a single dependency check
+		CacheTTL:     getEnv("HEALTH_CACHE_TTL", 5*time.Second),     // how long a dependency check result is reused
+	}
+}
+
+func loadMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		Enabled:   getEnv("METRICS_ENABLED", true),
+		Namespace: getEnv("METRICS_NAMESPACE", "xarch"), // prefix of every exported metric name
+	}
+}
+
+func loadTracingConfig() TracingConfig {
+	return TracingConfig{
+		Exporter:    getEnv("TRACING_EXPORTER", "none"),
+		Endpoint:    getEnv("TRACING_OTLP_ENDPOINT", "localhost:4317"), // OTLP/gRPC collector address
+		Insecure:    getEnv("TRACING_OTLP_INSECURE", true),             // plaintext connection to the collector
+		ServiceName: getEnv("TRACING_SERVICE_NAME", "xarch"),
+		SampleRatio: getEnv("TRACING_SAMPLE_RATIO", 1.0), // fraction of new traces recorded, parents are always honoured
+	}
+}
+
+func loadAccessLogConfig() AccessLogConfig {
+	return AccessLogConfig{
+		Enabled:       getEnv("ACCESS_LOG_ENABLED", true),
+		SampleRatio:   getEnv("ACCESS_LOG_SAMPLE_RATIO", 1.0), // fraction of successful requests logged, 5xx are always logged
+		SkipPaths:     getEnvList("ACCESS_LOG_SKIP_PATHS", []string{"/health", "/livez", "/readyz", "/startupz", "/metrics"}),
+		LogHeaders:    getEnv("ACCESS_LOG_HEADERS", false),
+		LogBody:       getEnv("ACCESS_LOG_BODY", false), // JSON request bodies only
+		BodyMaxBytes:  getEnv("ACCESS_LOG_BODY_MAX_BYTES", 4096),
+		RedactHeaders: getEnvList("ACCESS_LOG_REDACT_HEADERS", []string{"Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}),
+		RedactFields:  getEnvList("ACCESS_LOG_REDACT_FIELDS", []string{"password", "token", "access_token", "refresh_token", "secret"}),
+	}
+}
+
+func loadAuthConfig() AuthConfig {
+	return AuthConfig{
+		Secret:             getEnv("JWT_SECRET", ""),
+		JWKSFile:           getEnv("JWT_JWKS_FILE", ""),
+		JWKSReloadInterval: getEnv("JWT_JWKS_RELOAD_INTERVAL", time.Minute), // how often the JWKS file is checked for rotated keys
+		Issuer:             getEnv("JWT_ISSUER", "xarch"),
+		Audience:           getEnv("JWT_AUDIENCE", "xarch"),
+		ClockSkew:          getEnv("JWT_CLOCK_SKEW", 30*time.Second), // tolerance applied to exp, nbf and iat
+		SigningKeyID:       getEnv("JWT_SIGNING_KEY_ID", ""),
+		AccessTokenTTL:     getEnv("JWT_ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL:    getEnv("JWT_REFRESH_TOKEN_TTL", 30*24*time.Hour),
+		DenylistStore:      getEnv("AUTH_DENYLIST_STORE", "database"),
+		PublicMethods:      getEnvList("GRPC_PUBLIC_METHODS", nil),
+	}
+}
+
+func loadRBACConfig() RBACConfig {
+	return RBACConfig{
+		// role=permission,permission;role=permission, "*" and "resource:*" are wildcards
+		RolePermissions: getEnvMap("RBAC_ROLE_PERMISSIONS", map[string][]string{
+			"admin":    {"*"},
+			"employee": {"users:read"},
+		}),
+	}
+}
+
+func loadPaginationConfig() PaginationConfig {
+	return PaginationConfig{
+		CursorSecret: getEnv("PAGINATION_CURSOR_SECRET", ""),
+	}
+}
+
+func loadLifecycleConfig() LifecycleConfig {
+	return LifecycleConfig{
+		StartTimeout:    getEnv("START_TIMEOUT", 30*time.Second),       // budget for each component to become ready
+		ShutdownTimeout: getEnv("SHUTDOWN_TIMEOUT", 10*time.Second),    // budget shared by all components to stop
+		DrainDelay:      getEnv("SHUTDOWN_DRAIN_DELAY", 3*time.Second), // time between readiness failing and the servers stopping
+	}
+}
+
+func loadConnectConfig() ConnectConfig {
+	return ConnectConfig{
+		MaxAttempts:    getEnv("CONNECT_MAX_ATTEMPTS", 10),
+		Timeout:        getEnv("CONNECT_TIMEOUT", time.Minute),
+		InitialBackoff: getEnv("CONNECT_INITIAL_BACKOFF", 500*time.Millisecond), // doubled after every failed attempt
+		MaxBackoff:     getEnv("CONNECT_MAX_BACKOFF", 10*time.Second),
+		StartDegraded:  getEnv("CONNECT_START_DEGRADED", false),
+	}
+}
+
+func loadMigrateConfig() MigrateConfig {
+	return MigrateConfig{
+		OnStartup:   getEnv("MIGRATE_ON_STARTUP", false),
+		Sets:        getEnvList("MIGRATE_SETS", []string{"ddl", "dml"}),
+		Table:       getEnv("MIGRATE_TABLE", "schema_migrations"),
+		LockTimeout: getEnv("MIGRATE_LOCK_TIMEOUT", time.Minute), // how long a replica waits for another one to finish migrating
+	}
+}
+
+func loadAdminConfig() AdminConfig {
+	return AdminConfig{
+		Enabled: getEnv("ADMIN_ENABLED", false), // expose the /admin routes
+		Token:   getEnv("ADMIN_TOKEN", ""),      // bearer token required by the /admin routes
 	}
 }
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ConfigFileEnv selects the base configuration file, YAML or JSON
+	ConfigFileEnv = "CONFIG_FILE"
+	// ProfileEnv selects the overlay applied on top of the base file, e.g. APP_ENV=staging
+	// reads config.staging.yaml next to config.yaml
+	ProfileEnv = "APP_ENV"
+
+	defaultConfigFile = "config.yaml"
+)
+
+// mapKeys are read with getEnvMap, a file supplies them as a map of lists, e.g. rbac: {role_permissions: {admin: ["*"]}}
+var mapKeys = map[string]bool{"RBAC_ROLE_PERMISSIONS": true}
+
+// layer holds flattened values read from one configuration file
+type layer struct {
+	source string
+	values map[string]string
+}
+
+// loadLayers reads the base configuration file and the overlay of the active profile.
+// Missing files are skipped, so the application still runs from environment variables only.
+func loadLayers() ([]layer, error) {
+	base := os.Getenv(ConfigFileEnv)
+	if base == "" {
+		base = defaultConfigFile
+	}
+
+	var layers []layer
+
+	baseLayer, err := readLayer(base, SourceFile+":"+base)
+	if err != nil {
+		return nil, err
+	}
+	if baseLayer != nil {
+		layers = append(layers, *baseLayer)
+	}
+
+	if profile := os.Getenv(ProfileEnv); profile != "" {
+		overlay := profileFile(base, profile)
+		profileLayer, err := readLayer(overlay, SourceProfile+":"+overlay)
+		if err != nil {
+			return nil, err
+		}
+		if profileLayer != nil {
+			layers = append(layers, *profileLayer)
+		}
+	}
+
+	return layers, nil
+}
+
+// profileFile returns the overlay path for a profile, config.yaml becomes config.<profile>.yaml
+func profileFile(base, profile string) string {
+	ext := filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "." + profile + ext
+}
+
+// readLayer parses a YAML or JSON file into environment keys. It returns nil when the file does not exist.
+func readLayer(path, source string) (*layer, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	var document map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.UseNumber()
+		err = decoder.Decode(&document)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &document)
+	default:
+		return nil, fmt.Errorf("read %s: unsupported configuration format, use .yaml, .yml or .json", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	if err := flatten("", document, values); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	return &layer{source: source, values: values}, nil
+}
+
+// flatten turns nested maps into environment keys by joining the path with underscores,
+// so both `DB_TYPE: postgres` and `db: {type: postgres}` supply DB_TYPE. Lists are joined
+// with commas like getEnvList reads them, the maps of mapKeys are written as name=a,b;name=c.
+func flatten(prefix string, document map[string]any, values map[string]string) error {
+	for key, value := range document {
+		name := strings.ToUpper(key)
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+
+		switch v := value.(type) {
+		case map[string]any:
+			if !mapKeys[name] {
+				if err := flatten(name, v, values); err != nil {
+					return err
+				}
+				continue
+			}
+			entries, err := mapValue(name, v)
+			if err != nil {
+				return err
+			}
+			values[name] = entries
+		case []any:
+			if mapKeys[name] {
+				return fmt.Errorf("%s must be a map of names to lists, e.g. admin: [\"*\"]", name)
+			}
+			list, err := listValue(name, v)
+			if err != nil {
+				return err
+			}
+			values[name] = list
+		case nil:
+			continue
+		default:
+			values[name] = fmt.Sprint(v)
+		}
+	}
+	return nil
+}
+
+// listValue joins the items of a list with commas, every item must be a scalar
+func listValue(name string, list []any) (string, error) {
+	items := make([]string, 0, len(list))
+	for _, item := range list {
+		switch item.(type) {
+		case map[string]any, []any, nil:
+			return "", fmt.Errorf("%s must be a list of values, got %v", name, item)
+		}
+		items = append(items, fmt.Sprint(item))
+	}
+	return strings.Join(items, ","), nil
+}
+
+// mapValue writes a map of lists in the name=a,b;name=c form read by getEnvMap
+func mapValue(name string, document map[string]any) (string, error) {
+	entries := make([]string, 0, len(document))
+	for key, value := range document {
+		var list string
+		switch v := value.(type) {
+		case []any:
+			var err error
+			if list, err = listValue(name+"."+key, v); err != nil {
+				return "", err
+			}
+		case map[string]any:
+			return "", fmt.Errorf("%s.%s must be a list of values", name, key)
+		case nil:
+		default:
+			list = fmt.Sprint(v)
+		}
+		entries = append(entries, key+"="+list)
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ";"), nil
+}
@@ -0,0 +1,208 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadLayerPrecedence(t *testing.T) {
+	dir := t.TempDir()
+
+	base := writeFile(t, dir, "config.yaml", `
+DB_TYPE: mysql
+db:
+  server: base-host
+  name: base-db
+redis:
+  host: base-redis
+LOG_LEVEL: info
+`)
+	writeFile(t, dir, "config.staging.yaml", `
+db:
+  server: staging-host
+LOG_LEVEL: warn
+`)
+	secret := writeFile(t, dir, "db_pass", "s3cret\n")
+
+	t.Setenv(ConfigFileEnv, base)
+	t.Setenv(ProfileEnv, "staging")
+	t.Setenv("LOG_LEVEL", "error")
+	t.Setenv("DB_PASS_FILE", secret)
+
+	cfg, _ := Load()
+
+	tests := []struct {
+		name       string
+		key        string
+		got        string
+		want       string
+		wantSource string
+	}{
+		{
+			name:       "base file",
+			key:        "DB_TYPE",
+			got:        cfg.Database.Type,
+			want:       "mysql",
+			wantSource: SourceFile + ":" + base,
+		},
+		{
+			name:       "nested key in base file",
+			key:        "REDIS_HOST",
+			got:        cfg.Redis.Host,
+			want:       "base-redis",
+			wantSource: SourceFile + ":" + base,
+		},
+		{
+			name:       "profile overrides base file",
+			key:        "DB_SERVER",
+			got:        cfg.Database.MySQLDB.DBServer,
+			want:       "staging-host",
+			wantSource: SourceProfile + ":" + filepath.Join(dir, "config.staging.yaml"),
+		},
+		{
+			name:       "environment overrides profile",
+			key:        "LOG_LEVEL",
+			got:        cfg.Logger.LogLevel,
+			want:       "error",
+			wantSource: SourceEnvironment,
+		},
+		{
+			name:       "secret file",
+			key:        "DB_PASS",
+			got:        cfg.Database.MySQLDB.DBPass,
+			want:       "s3cret",
+			wantSource: SourceSecret + ":" + secret,
+		},
+		{
+			name:       "default",
+			key:        "REDIS_PORT",
+			got:        strconv.Itoa(cfg.Redis.Port),
+			want:       "6379",
+			wantSource: SourceDefault,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.key, tt.got, tt.want)
+			}
+			if source := SourceOf(tt.key); source != tt.wantSource {
+				t.Errorf("SourceOf(%s) = %q, want %q", tt.key, source, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestLoadJSONLayer(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(ConfigFileEnv, writeFile(t, dir, "config.json", `{"PORT": 8080, "grpc": {"port": 8081}}`))
+
+	cfg, _ := Load()
+
+	if cfg.Http.Port != 8080 {
+		t.Errorf("Http.Port = %d, want 8080", cfg.Http.Port)
+	}
+	if cfg.Grpc.Port != 8081 {
+		t.Errorf("Grpc.Port = %d, want 8081", cfg.Grpc.Port)
+	}
+}
+
+func TestLoadLayerLists(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		check   func(t *testing.T, cfg Config)
+	}{
+		{
+			name: "yaml list",
+			file: "config.yaml",
+			content: `
+access_log:
+  skip_paths: [/health, /metrics]
+`,
+			check: func(t *testing.T, cfg Config) {
+				if got := strings.Join(cfg.AccessLog.SkipPaths, " "); got != "/health /metrics" {
+					t.Errorf("AccessLog.SkipPaths = %v, want [/health /metrics]", cfg.AccessLog.SkipPaths)
+				}
+			},
+		},
+		{
+			name:    "json list",
+			file:    "config.json",
+			content: `{"migrate": {"sets": ["ddl"]}, "grpc": {"public_methods": ["/xarch.Auth/Login", "/xarch.Auth/Refresh"]}}`,
+			check: func(t *testing.T, cfg Config) {
+				if got := strings.Join(cfg.Migrate.Sets, " "); got != "ddl" {
+					t.Errorf("Migrate.Sets = %v, want [ddl]", cfg.Migrate.Sets)
+				}
+				if got := len(cfg.Auth.PublicMethods); got != 2 {
+					t.Errorf("Auth.PublicMethods = %v, want 2 methods", cfg.Auth.PublicMethods)
+				}
+			},
+		},
+		{
+			name: "role map",
+			file: "config.yaml",
+			content: `
+rbac:
+  role_permissions:
+    admin: ["*"]
+    employee: [users:read, users:write]
+`,
+			check: func(t *testing.T, cfg Config) {
+				got := cfg.RBAC.RolePermissions
+				if len(got) != 2 || strings.Join(got["admin"], ",") != "*" || strings.Join(got["employee"], ",") != "users:read,users:write" {
+					t.Errorf("RBAC.RolePermissions = %v", got)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ConfigFileEnv, writeFile(t, t.TempDir(), tt.file, tt.content))
+
+			cfg, _ := Load()
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestLoadLayerRoleMapAsList(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(ConfigFileEnv, writeFile(t, dir, "config.yaml", "rbac:\n  role_permissions: [admin]\n"))
+
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "RBAC_ROLE_PERMISSIONS") {
+		t.Errorf("Load() error = %v, want error for RBAC_ROLE_PERMISSIONS", err)
+	}
+}
+
+func TestLoadInvalidLayerValue(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(ConfigFileEnv, writeFile(t, dir, "config.yaml", "PORT: not-a-number\n"))
+
+	if _, err := Load(); err == nil {
+		t.Error("Load() error = nil, want error for invalid PORT")
+	}
+}
+
+func TestLoadInvalidEnvironmentValue(t *testing.T) {
+	t.Setenv("PORT", "80a")
+
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "PORT from environment") {
+		t.Errorf("Load() error = %v, want error for invalid PORT", err)
+	}
+}
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Configuration value sources. File based sources are reported with the file path,
+// for example "file:config.yaml" or "secret:/run/secrets/db".
+const (
+	SourceDefault     = "default"
+	SourceFile        = "file"
+	SourceProfile     = "profile"
+	SourceEnvironment = "environment"
+	SourceSecret      = "secret"
+
+	// secretSuffix marks an environment variable holding the path of a file with the real value
+	secretSuffix = "_FILE"
+)
+
+// sources remembers which source supplied every environment key read by the loaders
+var sources = &sourceRegistry{keys: make(map[string]string)}
+
+type sourceRegistry struct {
+	mu     sync.RWMutex
+	keys   map[string]string
+	layers []layer
+	errs   []error
+}
+
+// KeySource pairs an environment key with the source that supplied it
+type KeySource struct {
+	Key    string `json:"key" yaml:"key"`
+	Source string `json:"source" yaml:"source"`
+}
+
+// reset replaces the file layers and forgets previously recorded sources
+func (r *sourceRegistry) reset(layers []layer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys = make(map[string]string)
+	r.layers = layers
+	r.errs = nil
+}
+
+func (r *sourceRegistry) set(key, source string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys[key] = source
+}
+
+func (r *sourceRegistry) get(key string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if source, ok := r.keys[key]; ok {
+		return source
+	}
+	return SourceDefault
+}
+
+func (r *sourceRegistry) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errs = append(r.errs, err)
+}
+
+// lookupFile returns the value of the key from the file layer with the highest precedence
+func (r *sourceRegistry) lookupFile(key string) (string, string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for i := len(r.layers) - 1; i >= 0; i-- {
+		if value, ok := r.layers[i].values[key]; ok {
+			return value, r.layers[i].source, true
+		}
+	}
+	return "", "", false
+}
+
+// getEnv resolves a value through every configuration layer and records where it came from.
+// Precedence from lowest to highest: default, base file, profile file, environment, *_FILE secret.
+func getEnv[T any](key string, defaultValue T) T {
+	if path, ok := os.LookupEnv(key + secretSuffix); ok && path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			sources.fail(fmt.Errorf("%s%s: %w", key, secretSuffix, err))
+			return defaultValue
+		}
+		return parseLayerValue(key, strings.TrimRight(string(content), "\r\n"), SourceSecret+":"+path, defaultValue)
+	}
+
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return parseLayerValue(key, value, SourceEnvironment, defaultValue)
+	}
+
+	if value, source, ok := sources.lookupFile(key); ok {
+		return parseLayerValue(key, value, source, defaultValue)
+	}
+
+	sources.set(key, SourceDefault)
+	return defaultValue
+}
+
+// getEnvList reads a comma separated list, e.g. ACCESS_LOG_SKIP_PATHS=/livez,/readyz
+func getEnvList(key string, defaultValue []string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, strings.Join(defaultValue, ",")), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvMap reads a list of keys with their values, e.g. RBAC_ROLE_PERMISSIONS=admin=*;employee=users:read,users:write.
+// A key without "=" is kept with no values so validation can report it.
+func getEnvMap(key string, defaultValue map[string][]string) map[string][]string {
+	var defaults []string
+	for name, values := range defaultValue {
+		defaults = append(defaults, name+"="+strings.Join(values, ","))
+	}
+	sort.Strings(defaults)
+
+	values := make(map[string][]string)
+	for _, entry := range strings.Split(getEnv(key, strings.Join(defaults, ";")), ";") {
+		name, list, _ := strings.Cut(entry, "=")
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		values[name] = nil
+		for _, value := range strings.Split(list, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values[name] = append(values[name], value)
+			}
+		}
+	}
+	return values
+}
+
+// parseLayerValue converts a raw value of a layer into the type of the default value,
+// an invalid value keeps the default and is reported by Load
+func parseLayerValue[T any](key, raw, source string, defaultValue T) T {
+	var (
+		value any
+		err   error
+	)
+
+	switch any(defaultValue).(type) {
+	case string:
+		value = raw
+	case int:
+		value, err = strconv.Atoi(raw)
+	case uint64:
+		value, err = strconv.ParseUint(raw, 10, 64)
+	case float64:
+		value, err = strconv.ParseFloat(raw, 64)
+	case bool:
+		value, err = strconv.ParseBool(raw)
+	case time.Duration:
+		value, err = time.ParseDuration(raw)
+	default:
+		err = fmt.Errorf("unsupported type %T", defaultValue)
+	}
+
+	if err != nil {
+		sources.fail(fmt.Errorf("%s from %s: %w", key, source, err))
+		return defaultValue
+	}
+
+	sources.set(key, source)
+	return value.(T)
+}
+
+// SourceOf returns the source that supplied the given environment key
+func SourceOf(key string) string {
+	return sources.get(key)
+}
+
+// Sources returns every environment key read by the loaders with its source, sorted by key
+func Sources() []KeySource {
+	sources.mu.RLock()
+	defer sources.mu.RUnlock()
+
+	result := make([]KeySource, 0, len(sources.keys))
+	for key, source := range sources.keys {
+		result = append(result, KeySource{Key: key, Source: source})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+
+	return result
+}
@@ -0,0 +1,410 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	supportedDatabaseTypes   = []string{"postgres", "mysql", "sqlite", "sqlserver", "cockroach"}
+	supportedEncryptModes    = []string{"disable", "false", "true", "strict"}
+	supportedSSLModes        = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	supportedLogOutputModes  = []string{"terminal", "file", "both"}
+	supportedLogLevels       = []string{"debug", "info", "warn", "error"}
+	supportedResponseMethod  = []string{"file", "http"}
+	supportedClientAuth      = []string{"none", "request", "require", "verify-if-given", "require-and-verify"}
+	supportedTLSVersions     = []string{"1.2", "1.3"}
+	supportedTraceExporters  = []string{"none", "stdout", "otlp"}
+	supportedDenylistStores  = []string{"database", "redis"}
+	supportedReplicaPolicies = []string{"random", "round-robin"}
+	supportedMigrationSets   = []string{"ddl", "dml"}
+
+	tableNamePattern  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	metricNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	permissionPattern = regexp.MustCompile(`^(\*|[a-z0-9_.-]+(:(\*|[a-z0-9_.-]+))?)$`)
+)
+
+type (
+	// Violation describes a single invalid configuration value
+	Violation struct {
+		Env      string
+		Value    string
+		Expected string
+		Source   string
+	}
+
+	// ValidationError aggregates every violation found in the configuration
+	ValidationError struct {
+		Violations []Violation
+	}
+
+	// violations collects violations while a struct is validated
+	violations struct {
+		items []Violation
+	}
+)
+
+func (e *ValidationError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "invalid configuration (%d problems):", len(e.Violations))
+	for _, v := range e.Violations {
+		fmt.Fprintf(&sb, "\n  - %s=%q (source: %s): expected %s", v.Env, v.Value, v.Source, v.Expected)
+	}
+	return sb.String()
+}
+
+// check records a violation for the environment key when ok is false
+func (v *violations) check(ok bool, key string, value any, expected string) {
+	if ok {
+		return
+	}
+	v.items = append(v.items, Violation{
+		Env:      key,
+		Value:    fmt.Sprint(value),
+		Expected: expected,
+		Source:   SourceOf(key),
+	})
+}
+
+// port records a violation when the value is not a valid TCP port
+func (v *violations) port(key string, value int) {
+	v.check(value > 0 && value <= 65535, key, value, "a port between 1 and 65535")
+}
+
+// required records a violation when the value is empty
+func (v *violations) required(key, value string) {
+	v.check(strings.TrimSpace(value) != "", key, value, "a non-empty value")
+}
+
+// oneOf records a violation when the value is not one of the allowed values
+func (v *violations) oneOf(key, value string, allowed []string) {
+	v.check(slices.Contains(allowed, value), key, value, "one of "+strings.Join(allowed, ", "))
+}
+
+// merge appends the violations carried by err
+func (v *violations) merge(err error) {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		v.items = append(v.items, validationErr.Violations...)
+	}
+}
+
+func (v *violations) err() error {
+	if len(v.items) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: v.items}
+}
+
+// Validate checks the whole configuration and reports every violation at once
+func (c Config) Validate() error {
+	var v violations
+
+	v.merge(c.Http.Validate())
+	v.merge(c.Grpc.Validate())
+	v.merge(c.Database.Validate())
+	if c.MongoDB.Enabled {
+		v.merge(c.MongoDB.Validate())
+	}
+	if c.Redis.Enabled {
+		v.merge(c.Redis.Validate())
+	}
+	v.merge(c.Logger.Validate())
+	v.merge(c.ResponseManager.Validate())
+	v.merge(c.Lifecycle.Validate())
+	v.merge(c.Connect.Validate())
+	v.merge(c.Migrate.Validate())
+	v.merge(c.Admin.Validate())
+	v.merge(c.Health.Validate())
+	v.merge(c.Metrics.Validate())
+	v.merge(c.Tracing.Validate())
+	v.merge(c.AccessLog.Validate())
+	v.merge(c.Auth.Validate())
+	v.merge(c.RBAC.Validate())
+	v.merge(c.Pagination.Validate())
+
+	v.check(c.Http.Port != c.Grpc.Port, "GRPC_PORT", c.Grpc.Port, "a port different from PORT")
+	// A degraded start has no database to migrate before the servers start
+	v.check(!c.Migrate.OnStartup || !c.Connect.StartDegraded, "MIGRATE_ON_STARTUP", c.Migrate.OnStartup, "false when CONNECT_START_DEGRADED is true")
+	v.check(c.Auth.DenylistStore != "redis" || c.Redis.Enabled, "AUTH_DENYLIST_STORE", c.Auth.DenylistStore, "database when REDIS_ENABLED is false")
+
+	return v.err()
+}
+
+func (c HttpServer) Validate() error {
+	var v violations
+
+	v.required("SERVER", c.Server)
+	v.port("PORT", c.Port)
+	if c.UsingSecure {
+		v.merge(c.TLS.validate("TLS_"))
+	}
+
+	return v.err()
+}
+
+func (c GrpcServer) Validate() error {
+	var v violations
+
+	v.required("GRPC_SERVER", c.Server)
+	v.port("GRPC_PORT", c.Port)
+	v.check(c.HealthCheckInterval > 0, "GRPC_HEALTH_CHECK_INTERVAL", c.HealthCheckInterval, "a positive duration")
+	if c.UsingSecure {
+		v.merge(c.TLS.validate("GRPC_TLS_"))
+	}
+
+	return v.err()
+}
+
+// validate checks TLS settings whose environment keys start with prefix, e.g. TLS_ or GRPC_TLS_
+func (c TLSConfig) validate(prefix string) error {
+	var v violations
+
+	v.required(prefix+"CERT_FILE", c.CertFile)
+	v.required(prefix+"KEY_FILE", c.KeyFile)
+	v.oneOf(prefix+"CLIENT_AUTH", c.ClientAuth, supportedClientAuth)
+	if c.ClientAuth == "verify-if-given" || c.ClientAuth == "require-and-verify" {
+		v.required(prefix+"CLIENT_CA_FILE", c.ClientCAFile)
+	}
+	v.oneOf(prefix+"MIN_VERSION", c.MinVersion, supportedTLSVersions)
+	v.check(c.ReloadInterval >= 0, prefix+"RELOAD_INTERVAL", c.ReloadInterval, "zero (disabled) or a positive duration")
+
+	return v.err()
+}
+
+func (c DatabaseConfig) Validate() error {
+	var v violations
+
+	v.oneOf("DB_TYPE", c.Type, supportedDatabaseTypes)
+
+	switch c.Type {
+	case "postgres":
+		v.required("DB_SERVER", c.PostgresDB.DBServer)
+		v.port("DB_PORT", c.PostgresDB.DBPort)
+		v.required("DB_USER", c.PostgresDB.DBUser)
+		v.required("DB_NAME", c.PostgresDB.DBName)
+		v.oneOf("DB_SSL_MODE", c.PostgresDB.SSLMode, supportedSSLModes)
+	case "mysql":
+		v.required("DB_SERVER", c.MySQLDB.DBServer)
+		v.port("DB_PORT", c.MySQLDB.DBPort)
+		v.required("DB_USER", c.MySQLDB.DBUser)
+		v.required("DB_NAME", c.MySQLDB.DBName)
+		v.required("DB_CHARSET", c.MySQLDB.Charset)
+	case "sqlite":
+		v.required("DB_PATH", c.SQLiteDB.DBPath)
+	case "sqlserver":
+		v.required("DB_SERVER", c.SQLServerDB.DBServer)
+		v.port("DB_PORT", c.SQLServerDB.DBPort)
+		v.required("DB_USER", c.SQLServerDB.DBUser)
+		v.required("DB_NAME", c.SQLServerDB.DBName)
+		v.oneOf("DB_ENCRYPT", c.SQLServerDB.Encrypt, supportedEncryptModes)
+	case "cockroach":
+		v.required("DB_SERVER", c.CockroachDB.DBServer)
+		v.port("DB_PORT", c.CockroachDB.DBPort)
+		v.required("DB_USER", c.CockroachDB.DBUser)
+		v.required("DB_NAME", c.CockroachDB.DBName)
+		v.oneOf("DB_SSL_MODE", c.CockroachDB.SSLMode, supportedSSLModes)
+	}
+
+	v.check(c.DBMaxIdleCon >= 0, "DB_MAX_IDLE_CON", c.DBMaxIdleCon, "zero or a positive number")
+	v.check(c.DBMaxOpenCon >= 0, "DB_MAX_OPEN_CON", c.DBMaxOpenCon, "zero (unlimited) or a positive number")
+	v.check(c.DBMaxOpenCon == 0 || c.DBMaxIdleCon <= c.DBMaxOpenCon,
+		"DB_MAX_IDLE_CON", c.DBMaxIdleCon, "a value not greater than DB_MAX_OPEN_CON")
+	v.check(c.DBMaxLifeTime >= 0, "DB_MAX_LIFE_TIME", c.DBMaxLifeTime, "zero or a positive number of minutes")
+	v.check(c.DBSlowThreshold >= 0, "DB_SLOW_QUERY_THRESHOLD", c.DBSlowThreshold, "zero (disabled) or a positive duration")
+	if len(c.Replicas) > 0 {
+		v.oneOf("DB_REPLICA_POLICY", c.ReplicaPolicy, supportedReplicaPolicies)
+	}
+
+	return v.err()
+}
+
+func (c MongoDBConfig) Validate() error {
+	var v violations
+
+	v.check(strings.HasPrefix(c.URI, "mongodb://") || strings.HasPrefix(c.URI, "mongodb+srv://"),
+		"MONGODB_URI", RedactURI(c.URI), "a URI starting with mongodb:// or mongodb+srv://")
+	v.required("MONGODB_DATABASE", c.Database)
+	v.check(c.MaxPoolSize == 0 || c.MinPoolSize <= c.MaxPoolSize,
+		"MONGODB_MIN_POOL_SIZE", c.MinPoolSize, "a value not greater than MONGODB_MAX_POOL_SIZE")
+	v.check(c.Timeout > 0, "MONGODB_TIMEOUT", c.Timeout, "a positive duration such as 10s")
+
+	return v.err()
+}
+
+func (c RedisConfig) Validate() error {
+	var v violations
+
+	v.required("REDIS_HOST", c.Host)
+	v.port("REDIS_PORT", c.Port)
+	v.check(c.DB >= 0, "REDIS_DB", c.DB, "zero or a positive database index")
+	v.check(c.MaxRetries >= -1, "REDIS_MAX_RETRIES", c.MaxRetries, "-1 (disabled) or a positive number")
+	v.check(c.PoolSize > 0, "REDIS_POOL_SIZE", c.PoolSize, "a positive number")
+	v.check(c.MinIdleConns >= 0 && c.MinIdleConns <= c.PoolSize,
+		"REDIS_MIN_IDLE_CONNS", c.MinIdleConns, "a value between 0 and REDIS_POOL_SIZE")
+	v.check(c.DialTimeout > 0, "REDIS_DIAL_TIMEOUT", c.DialTimeout, "a positive duration such as 5s")
+
+	return v.err()
+}
+
+func (c LoggerConfig) Validate() error {
+	var v violations
+
+	v.oneOf("LOG_OUTPUT_MODE", c.OutputMode, supportedLogOutputModes)
+	v.oneOf("LOG_LEVEL", c.LogLevel, supportedLogLevels)
+	if c.OutputMode == "file" || c.OutputMode == "both" {
+		v.required("LOG_DIR", c.LogDir)
+	}
+
+	return v.err()
+}
+
+func (c ResponseManager) Validate() error {
+	var v violations
+
+	v.oneOf("RESPONSE_MANAGER_METHOD", c.Method, supportedResponseMethod)
+	v.required("RESPONSE_MANAGER_PATH", c.Path)
+	v.check(c.Interval > 0, "RESPONSE_MANAGER_INTERVAL", c.Interval, "a positive duration such as 5m")
+
+	return v.err()
+}
+
+func (c LifecycleConfig) Validate() error {
+	var v violations
+
+	v.check(c.StartTimeout > 0, "START_TIMEOUT", c.StartTimeout, "a positive duration such as 30s")
+	v.check(c.ShutdownTimeout > 0, "SHUTDOWN_TIMEOUT", c.ShutdownTimeout, "a positive duration such as 10s")
+	v.check(c.DrainDelay >= 0 && c.DrainDelay < c.ShutdownTimeout, "SHUTDOWN_DRAIN_DELAY", c.DrainDelay, "zero or a duration shorter than SHUTDOWN_TIMEOUT")
+
+	return v.err()
+}
+
+func (c ConnectConfig) Validate() error {
+	var v violations
+
+	v.check(c.MaxAttempts >= 0, "CONNECT_MAX_ATTEMPTS", c.MaxAttempts, "zero (until CONNECT_TIMEOUT) or a positive number")
+	v.check(c.Timeout >= 0, "CONNECT_TIMEOUT", c.Timeout, "zero (no deadline) or a positive duration")
+	v.check(c.MaxAttempts > 0 || c.Timeout > 0, "CONNECT_MAX_ATTEMPTS", c.MaxAttempts, "a positive number when CONNECT_TIMEOUT is zero")
+	v.check(c.InitialBackoff > 0, "CONNECT_INITIAL_BACKOFF", c.InitialBackoff, "a positive duration such as 500ms")
+	v.check(c.MaxBackoff >= c.InitialBackoff, "CONNECT_MAX_BACKOFF", c.MaxBackoff, "a duration not shorter than CONNECT_INITIAL_BACKOFF")
+
+	return v.err()
+}
+
+func (c MigrateConfig) Validate() error {
+	var v violations
+
+	v.check(len(c.Sets) > 0, "MIGRATE_SETS", "", "a comma separated list of ddl, dml")
+	for _, set := range c.Sets {
+		v.oneOf("MIGRATE_SETS", set, supportedMigrationSets)
+	}
+	v.check(tableNamePattern.MatchString(c.Table), "MIGRATE_TABLE", c.Table, "a table name of letters, digits and underscores")
+	v.check(c.LockTimeout > 0, "MIGRATE_LOCK_TIMEOUT", c.LockTimeout, "a positive duration such as 1m")
+
+	return v.err()
+}
+
+func (c HealthConfig) Validate() error {
+	var v violations
+
+	v.check(c.CheckTimeout > 0, "HEALTH_CHECK_TIMEOUT", c.CheckTimeout, "a positive duration such as 2s")
+	v.check(c.CacheTTL >= 0, "HEALTH_CACHE_TTL", c.CacheTTL, "zero (no caching) or a positive duration")
+
+	return v.err()
+}
+
+func (c MetricsConfig) Validate() error {
+	var v violations
+
+	if c.Enabled {
+		v.check(metricNamePattern.MatchString(c.Namespace), "METRICS_NAMESPACE", c.Namespace, "letters, digits and underscores, not starting with a digit")
+	}
+
+	return v.err()
+}
+
+func (c TracingConfig) Validate() error {
+	var v violations
+
+	v.oneOf("TRACING_EXPORTER", c.Exporter, supportedTraceExporters)
+	if c.Exporter == "otlp" {
+		v.required("TRACING_OTLP_ENDPOINT", c.Endpoint)
+	}
+	if c.Exporter != "none" {
+		v.required("TRACING_SERVICE_NAME", c.ServiceName)
+		v.check(c.SampleRatio >= 0 && c.SampleRatio <= 1, "TRACING_SAMPLE_RATIO", c.SampleRatio, "a ratio between 0 and 1")
+	}
+
+	return v.err()
+}
+
+func (c AccessLogConfig) Validate() error {
+	var v violations
+
+	if c.Enabled {
+		v.check(c.SampleRatio >= 0 && c.SampleRatio <= 1, "ACCESS_LOG_SAMPLE_RATIO", c.SampleRatio, "a ratio between 0 and 1")
+		if c.LogBody {
+			v.check(c.BodyMaxBytes > 0, "ACCESS_LOG_BODY_MAX_BYTES", c.BodyMaxBytes, "a positive number of bytes")
+		}
+	}
+
+	return v.err()
+}
+
+func (c AuthConfig) Validate() error {
+	var v violations
+
+	if c.Secret != "" {
+		v.check(len(c.Secret) >= 32, "JWT_SECRET", redact(c.Secret), "a secret of at least 32 bytes for HS256")
+	}
+	v.check(c.JWKSReloadInterval >= 0, "JWT_JWKS_RELOAD_INTERVAL", c.JWKSReloadInterval, "zero (disabled) or a positive duration")
+	v.check(c.ClockSkew >= 0, "JWT_CLOCK_SKEW", c.ClockSkew, "zero or a positive duration")
+	v.check(c.AccessTokenTTL > 0, "JWT_ACCESS_TOKEN_TTL", c.AccessTokenTTL, "a positive duration")
+	v.check(c.RefreshTokenTTL > c.AccessTokenTTL, "JWT_REFRESH_TOKEN_TTL", c.RefreshTokenTTL, "a duration longer than JWT_ACCESS_TOKEN_TTL")
+	v.oneOf("AUTH_DENYLIST_STORE", c.DenylistStore, supportedDenylistStores)
+
+	return v.err()
+}
+
+func (c RBACConfig) Validate() error {
+	var v violations
+
+	roles := make([]string, 0, len(c.RolePermissions))
+	for role := range c.RolePermissions {
+		roles = append(roles, role)
+	}
+	slices.Sort(roles)
+
+	for _, role := range roles {
+		permissions := c.RolePermissions[role]
+		v.check(len(permissions) > 0, "RBAC_ROLE_PERMISSIONS", role, "role=permission,... with at least one permission per role")
+		for _, permission := range permissions {
+			v.check(permissionPattern.MatchString(permission), "RBAC_ROLE_PERMISSIONS", role+"="+permission, `permissions written as "*", "resource:*" or "resource:action"`)
+		}
+	}
+
+	return v.err()
+}
+
+func (c PaginationConfig) Validate() error {
+	var v violations
+
+	if c.CursorSecret != "" {
+		v.check(len(c.CursorSecret) >= 32, "PAGINATION_CURSOR_SECRET", redact(c.CursorSecret), "a secret of at least 32 bytes")
+	}
+
+	return v.err()
+}
+
+func (c AdminConfig) Validate() error {
+	var v violations
+
+	if c.Enabled {
+		v.check(len(c.Token) >= 16, "ADMIN_TOKEN", redact(c.Token), "a token of at least 16 characters when ADMIN_ENABLED is true")
+	}
+
+	return v.err()
+}
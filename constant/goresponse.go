@@ -13,13 +13,23 @@ const (
 )
 
 const (
-	IsResponseSuccess   = "success"
-	IsResponseCreated   = "created"
-	IsResponseUpdated   = "updated"
-	IsResponseDeleted   = "deleted"
-	IsResponseRetrieved = "retrieved"
+	IsResponseSuccess       = "success"
+	IsResponseCreated       = "created"
+	IsResponseUpdated       = "updated"
+	IsResponseDeleted       = "deleted"
+	IsResponseRetrieved     = "retrieved"
+	IsResponseDataRetrieved = "data_retrieved"
 
 	ErrorInternalServer     = "internal_server_error"
+	ErrorBadRequest         = "bad_request"
+	ErrorValidationFailed   = "validation_failed"
+	ErrorDatabase           = "database_error"
+	ErrorUnauthorized       = "unauthorized"
+	ErrorForbidden          = "forbidden"
+	ErrorNotFound           = "not_found"
+	ErrorServiceUnavailable = "service_unavailable"
+	ErrorConflict           = "conflict"
+	ErrorTimeout            = "timeout"
 	ErrorConnectionRefused  = "connection_refused"
 	ErrorTooManyConnections = "too_many_connections"
 	ErrorConnectionTimeout  = "connection_timeout"
@@ -28,4 +38,19 @@ const (
 	ErrorAccessDenied       = "access_denied"
 	ErrorDriverError        = "driver_error"
 	ErrorSslTlsError        = "ssl_tls_error"
+
+	// Authentication
+	ErrorInvalidCredentials = "invalid_credentials"
+	ErrorTokenMissing       = "token_missing"
+	ErrorTokenInvalid       = "token_invalid"
+	ErrorTokenExpired       = "token_expired"
+	ErrorTokenNotYetValid   = "token_not_yet_valid"
+	ErrorTokenRevoked       = "token_revoked"
+
+	// Users
+	IsResponseUserCreated      = "user_created"
+	IsResponseUserUpdated      = "user_updated"
+	IsResponseUserDeleted      = "user_deleted"
+	ErrorUserNotFound          = "user_not_found"
+	ErrorResourceAlreadyExists = "resource_already_exists"
 )
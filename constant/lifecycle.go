@@ -0,0 +1,13 @@
+package constant
+
+// Lifecycle component names
+const (
+	ComponentTracing         = "tracing"
+	ComponentDatabase        = "database"
+	ComponentMongoDB         = "mongodb"
+	ComponentRedis           = "redis"
+	ComponentResponseManager = "response-manager"
+	ComponentHttpServer      = "http-server"
+	ComponentGrpcServer      = "grpc-server"
+	ComponentProbes          = "probes"
+)
@@ -0,0 +1,12 @@
+CREATE TABLE users (
+  id BIGSERIAL NOT NULL,
+  username VARCHAR(255) NOT NULL UNIQUE,
+  password VARCHAR(255) NOT NULL,
+  roles VARCHAR(255) NOT NULL,
+  salary DOUBLE PRECISION NOT NULL,
+  created_by BIGINT NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_by BIGINT NULL,
+  updated_at TIMESTAMP NULL,
+  PRIMARY KEY (id)
+);
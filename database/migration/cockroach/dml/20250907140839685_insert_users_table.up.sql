@@ -0,0 +1,4 @@
+-- CockroachDB generates ids with unique_rowid(), the row is identified by its username
+INSERT INTO users
+(username, password, roles, salary, created_by, created_at, updated_by, updated_at)
+VALUES('admin', '$2a$10$CqRJaUxheMCaRtocArRlAuePEH5wz21am/aAYbE5AjApAGi5zef/W', 'admin', 0.0, 1, '2025-09-07 14:13:09', NULL, NULL);
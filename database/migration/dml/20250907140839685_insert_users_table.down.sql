@@ -1,2 +0,0 @@
-DELETE FROM elsa.users
-WHERE id=1;
\ No newline at end of file
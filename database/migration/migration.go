@@ -0,0 +1,9 @@
+package migration
+
+import "embed"
+
+// Files holds the SQL migrations, <DB_TYPE>/<set>/<version>_<name>.up.sql and .down.sql,
+// so the binary migrates without the source tree
+//
+//go:embed */ddl/*.sql */dml/*.sql
+var Files embed.FS
@@ -0,0 +1,13 @@
+CREATE TABLE `refresh_tokens` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  `user_id` BIGINT UNSIGNED NOT NULL,
+  `family_id` CHAR(36) NOT NULL,
+  `token_hash` CHAR(64) NOT NULL UNIQUE,
+  `expires_at` DATETIME NOT NULL,
+  `replaced_by` BIGINT UNSIGNED NULL,
+  `revoked_at` DATETIME NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX `idx_refresh_tokens_family_id` (`family_id`),
+  CONSTRAINT `fk_refresh_tokens_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,6 @@
+CREATE TABLE `revoked_tokens` (
+  `jti` CHAR(36) NOT NULL,
+  `expires_at` DATETIME NOT NULL,
+  PRIMARY KEY (`jti`),
+  INDEX `idx_revoked_tokens_expires_at` (`expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
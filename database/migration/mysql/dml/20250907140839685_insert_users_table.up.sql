@@ -1,3 +1,3 @@
-INSERT INTO elsa.users
+INSERT INTO users
 (id, username, password, roles, salary, created_by, created_at, updated_by, updated_at)
 VALUES(1, 'admin', '$2a$10$CqRJaUxheMCaRtocArRlAuePEH5wz21am/aAYbE5AjApAGi5zef/W', 'admin', 0.0, 1, '2025-09-07 14:13:09', NULL, NULL);
\ No newline at end of file
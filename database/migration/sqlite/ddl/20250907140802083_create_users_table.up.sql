@@ -0,0 +1,11 @@
+CREATE TABLE users (
+  id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+  username VARCHAR(255) NOT NULL UNIQUE,
+  password VARCHAR(255) NOT NULL,
+  roles VARCHAR(255) NOT NULL,
+  salary REAL NOT NULL,
+  created_by INTEGER NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_by INTEGER NULL,
+  updated_at DATETIME NULL
+);
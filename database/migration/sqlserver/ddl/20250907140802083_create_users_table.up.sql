@@ -0,0 +1,12 @@
+CREATE TABLE users (
+  id BIGINT IDENTITY(1,1) NOT NULL,
+  username NVARCHAR(255) NOT NULL UNIQUE,
+  password NVARCHAR(255) NOT NULL,
+  roles NVARCHAR(255) NOT NULL,
+  salary FLOAT NOT NULL,
+  created_by BIGINT NOT NULL,
+  created_at DATETIME2 NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_by BIGINT NULL,
+  updated_at DATETIME2 NULL,
+  CONSTRAINT pk_users PRIMARY KEY (id)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE refresh_tokens (
+  id BIGINT IDENTITY(1,1) NOT NULL,
+  user_id BIGINT NOT NULL,
+  family_id CHAR(36) NOT NULL,
+  token_hash CHAR(64) NOT NULL UNIQUE,
+  expires_at DATETIME2 NOT NULL,
+  replaced_by BIGINT NULL,
+  revoked_at DATETIME2 NULL,
+  created_at DATETIME2 NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT pk_refresh_tokens PRIMARY KEY (id),
+  CONSTRAINT fk_refresh_tokens_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens (family_id);
@@ -0,0 +1,73 @@
+package auth
+
+import "time"
+
+const TokenTypeBearer = "Bearer"
+
+type (
+	// User is the part of the users table needed to authenticate a caller
+	User struct {
+		ID       uint
+		Username string
+		Password string
+		Roles    string
+	}
+
+	// RefreshToken is a stored refresh token, only the SHA-256 hash of the token is kept.
+	// Every rotation adds a token to the family of the login, presenting a used token revokes the family.
+	RefreshToken struct {
+		ID         uint `gorm:"primaryKey"`
+		UserID     uint
+		FamilyID   string
+		TokenHash  string
+		ExpiresAt  time.Time
+		ReplacedBy *uint
+		RevokedAt  *time.Time
+		CreatedAt  time.Time
+	}
+
+	// RevokedToken is a denylisted access token, kept until the token expires
+	RevokedToken struct {
+		JTI       string `gorm:"column:jti;primaryKey"`
+		ExpiresAt time.Time
+	}
+
+	LoginRequest struct {
+		Username string `json:"username" validate:"required,max=255"`
+		Password string `json:"password" validate:"required"`
+	}
+
+	RefreshRequest struct {
+		RefreshToken string `json:"refresh_token" validate:"required"`
+	}
+
+	LogoutRequest struct {
+		// RefreshToken is optional, when given its whole family is revoked
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	TokenPair struct {
+		AccessToken      string `json:"access_token"`
+		TokenType        string `json:"token_type"`
+		ExpiresIn        int64  `json:"expires_in"`
+		RefreshToken     string `json:"refresh_token"`
+		RefreshExpiresIn int64  `json:"refresh_expires_in"`
+	}
+)
+
+func (User) TableName() string {
+	return "users"
+}
+
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+func (RevokedToken) TableName() string {
+	return "revoked_tokens"
+}
+
+// Used reports whether the token was already rotated or revoked
+func (t RefreshToken) Used() bool {
+	return t.ReplacedBy != nil || t.RevokedAt != nil
+}
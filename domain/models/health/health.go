@@ -1,10 +1,16 @@
 package health
 
-import "database/sql"
+import (
+	"database/sql"
+
+	"go.risoftinc.com/xarch/utils/healthcheck"
+)
 
 type (
 	HealthMetric struct {
-		Status map[string]interface{} `json:"status"`
-		DB     sql.DBStats            `json:"database"`
+		// Status is the overall status: up, degraded or down
+		Status string                        `json:"status"`
+		Checks map[string]healthcheck.Result `json:"checks"`
+		DB     sql.DBStats                   `json:"database"`
 	}
 )
@@ -13,9 +13,14 @@ type Meta struct {
 	ErrorValidation map[string]string `json:"error_validation,omitempty"`
 }
 
+// Pagination of a list, Page is only set in page mode and the cursors only in cursor mode.
+// Total and TotalPages are nil when the list skipped counting its rows.
 type Pagination struct {
-	Page       int `json:"page"`
-	PerPage    int `json:"per_page"`
-	Total      int `json:"total"`
-	TotalPages int `json:"total_pages"`
+	Page       int    `json:"page,omitempty"`
+	PerPage    int    `json:"per_page"`
+	Total      *int   `json:"total,omitempty"`
+	TotalPages *int   `json:"total_pages,omitempty"`
+	HasNext    bool   `json:"has_next"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
@@ -0,0 +1,91 @@
+package user
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.risoftinc.com/xarch/utils/query"
+)
+
+type (
+	// Roles are stored comma separated in the users.roles column
+	Roles []string
+
+	User struct {
+		ID        uint       `json:"id" gorm:"primaryKey"`
+		Username  string     `json:"username"`
+		Password  string     `json:"-"`
+		Roles     Roles      `json:"roles"`
+		Salary    float64    `json:"salary"`
+		CreatedBy uint       `json:"created_by"`
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedBy *uint      `json:"updated_by,omitempty"`
+		UpdatedAt *time.Time `json:"updated_at,omitempty" gorm:"autoUpdateTime:false"`
+	}
+
+	CreateUserRequest struct {
+		Username string   `json:"username" validate:"required,min=3,max=255"`
+		Password string   `json:"password" validate:"required,min=8,max=72"`
+		Roles    []string `json:"roles" validate:"required,min=1,dive,required"`
+		Salary   float64  `json:"salary" validate:"gte=0"`
+	}
+
+	// UpdateUserRequest changes only the fields that are set
+	UpdateUserRequest struct {
+		ID       uint     `param:"id" json:"-" validate:"required"`
+		Username *string  `json:"username" validate:"omitempty,min=3,max=255"`
+		Password *string  `json:"password" validate:"omitempty,min=8,max=72"`
+		Roles    []string `json:"roles" validate:"omitempty,min=1,dive,required"`
+		Salary   *float64 `json:"salary" validate:"omitempty,gte=0"`
+	}
+)
+
+// QuerySchema lists the fields a user list can filter and sort on, the password is never one of them
+var QuerySchema = query.Schema{
+	Fields: map[string]query.Field{
+		"id":         {Type: query.Uint, Sortable: true},
+		"username":   {Type: query.String, Sortable: true},
+		"roles":      {Type: query.String, Operators: []query.Operator{query.OpLike}},
+		"salary":     {Type: query.Float, Sortable: true},
+		"created_by": {Type: query.Uint},
+		"created_at": {Type: query.Time, Sortable: true},
+	},
+	DefaultSort: []query.Sort{{Field: "id"}},
+}
+
+func (User) TableName() string {
+	return "users"
+}
+
+func (Roles) GormDataType() string {
+	return "string"
+}
+
+func (r Roles) Value() (driver.Value, error) {
+	return strings.Join(r, ","), nil
+}
+
+func (r *Roles) Scan(value any) error {
+	var raw string
+	switch v := value.(type) {
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	case nil:
+		*r = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported roles value %T", value)
+	}
+
+	*r = nil
+	for _, role := range strings.Split(raw, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			*r = append(*r, role)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	authModels "go.risoftinc.com/xarch/domain/models/auth"
+	"go.risoftinc.com/xarch/domain/repositories/instance"
+	"gorm.io/gorm"
+)
+
+// ErrRefreshTokenUsed is returned when a refresh token was rotated or revoked by a concurrent request
+var ErrRefreshTokenUsed = errors.New("refresh token was already used")
+
+type (
+	IAuthRepositories interface {
+		FindUserByUsername(ctx context.Context, username string) (*authModels.User, error)
+		FindUserByID(ctx context.Context, id uint) (*authModels.User, error)
+		CreateRefreshToken(ctx context.Context, token *authModels.RefreshToken) error
+		FindRefreshToken(ctx context.Context, tokenHash string) (*authModels.RefreshToken, error)
+		RotateRefreshToken(ctx context.Context, used *authModels.RefreshToken, next *authModels.RefreshToken) error
+		RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	}
+	AuthRepositories struct {
+		db *gorm.DB
+	}
+)
+
+func NewAuthRepositories(db *gorm.DB) IAuthRepositories {
+	return &AuthRepositories{
+		db: db,
+	}
+}
+
+func (repo AuthRepositories) FindUserByUsername(ctx context.Context, username string) (*authModels.User, error) {
+	var user authModels.User
+	if err := instance.Conn(ctx, repo.db).Where("username = ?", username).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (repo AuthRepositories) FindUserByID(ctx context.Context, id uint) (*authModels.User, error) {
+	var user authModels.User
+	if err := instance.Conn(ctx, repo.db).First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (repo AuthRepositories) CreateRefreshToken(ctx context.Context, token *authModels.RefreshToken) error {
+	return instance.Conn(ctx, repo.db).Create(token).Error
+}
+
+// FindRefreshToken reads the primary, a replica may not have the token issued or revoked a moment ago yet
+func (repo AuthRepositories) FindRefreshToken(ctx context.Context, tokenHash string) (*authModels.RefreshToken, error) {
+	var token authModels.RefreshToken
+	if err := instance.Conn(instance.WithPrimary(ctx), repo.db).Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken stores the next token and marks the used one as replaced in one transaction.
+// The update only matches an unused token, so two requests racing with the same token cannot both rotate it.
+func (repo AuthRepositories) RotateRefreshToken(ctx context.Context, used *authModels.RefreshToken, next *authModels.RefreshToken) error {
+	return instance.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&authModels.RefreshToken{}).
+			Where("id = ? AND replaced_by IS NULL AND revoked_at IS NULL", used.ID).
+			Update("replaced_by", next.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRefreshTokenUsed
+		}
+
+		return nil
+	})
+}
+
+// RevokeRefreshTokenFamily revokes every token issued since the login of the family
+func (repo AuthRepositories) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	return instance.Conn(ctx, repo.db).Model(&authModels.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
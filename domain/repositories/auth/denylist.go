@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.risoftinc.com/xarch/config"
+	authModels "go.risoftinc.com/xarch/domain/models/auth"
+	"go.risoftinc.com/xarch/domain/repositories/instance"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// denylistKeyPrefix namespaces the revoked token IDs in Redis
+const denylistKeyPrefix = "auth:denylist:"
+
+// errRedisUnavailable is returned by the Redis denylist built without a client, main refuses to start that way
+var errRedisUnavailable = errors.New("AUTH_DENYLIST_STORE=redis but no Redis client is connected")
+
+type (
+	// IDenylistRepositories stores the IDs (jti) of revoked access tokens until they expire
+	IDenylistRepositories interface {
+		Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+		IsRevoked(ctx context.Context, jti string) (bool, error)
+	}
+	DatabaseDenylistRepositories struct {
+		db *gorm.DB
+	}
+	RedisDenylistRepositories struct {
+		redis *redis.Client
+	}
+)
+
+// NewDenylistRepositories stores the denylist in Redis when AUTH_DENYLIST_STORE=redis, otherwise in the
+// revoked_tokens table. It never falls back to the database, a token revoked in one store would be accepted by the other.
+func NewDenylistRepositories(db *gorm.DB, redis *redis.Client, cfg config.Config) IDenylistRepositories {
+	if cfg.Auth.DenylistStore == "redis" {
+		return &RedisDenylistRepositories{
+			redis: redis,
+		}
+	}
+
+	return &DatabaseDenylistRepositories{
+		db: db,
+	}
+}
+
+// Revoke adds the token and removes the entries of tokens that expired meanwhile
+func (repo DatabaseDenylistRepositories) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	return instance.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at < ?", time.Now()).Delete(&authModels.RevokedToken{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&authModels.RevokedToken{JTI: jti, ExpiresAt: expiresAt}).Error
+	})
+}
+
+// IsRevoked reads the primary, so a token revoked a moment ago is not accepted by a lagging replica
+func (repo DatabaseDenylistRepositories) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	var count int64
+	err := instance.Conn(instance.WithPrimary(ctx), repo.db).Model(&authModels.RevokedToken{}).
+		Where("jti = ? AND expires_at >= ?", jti, time.Now()).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Revoke sets a key that Redis expires together with the token
+func (repo RedisDenylistRepositories) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	if repo.redis == nil {
+		return errRedisUnavailable
+	}
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return repo.redis.Set(ctx, denylistKeyPrefix+jti, 1, ttl).Err()
+}
+
+func (repo RedisDenylistRepositories) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	if repo.redis == nil {
+		return false, errRedisUnavailable
+	}
+	count, err := repo.redis.Exists(ctx, denylistKeyPrefix+jti).Result()
+	return count > 0, err
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.risoftinc.com/xarch/config"
+)
+
+func TestNewDenylistRepositoriesKeepsRedisStore(t *testing.T) {
+	cfg := config.Config{Auth: config.AuthConfig{DenylistStore: "redis"}}
+
+	// Without a client the Redis store fails instead of silently using the database
+	repo := NewDenylistRepositories(nil, nil, cfg)
+	if _, ok := repo.(*RedisDenylistRepositories); !ok {
+		t.Fatalf("NewDenylistRepositories() = %T, want the Redis store", repo)
+	}
+	if _, err := repo.IsRevoked(context.Background(), "jti"); !errors.Is(err, errRedisUnavailable) {
+		t.Errorf("IsRevoked() error = %v, want errRedisUnavailable", err)
+	}
+	if err := repo.Revoke(context.Background(), "jti", time.Now().Add(time.Minute)); !errors.Is(err, errRedisUnavailable) {
+		t.Errorf("Revoke() error = %v, want errRedisUnavailable", err)
+	}
+}
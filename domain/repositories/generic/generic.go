@@ -0,0 +1,265 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/domain/models/response"
+	"go.risoftinc.com/xarch/domain/repositories/instance"
+	"go.risoftinc.com/xarch/utils/query"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	// IRepository lists rows of the model T with a query spec checked against the schema of the repository
+	IRepository[T any] interface {
+		Find(ctx context.Context, spec query.Spec) ([]T, response.Pagination, error)
+	}
+	Repository[T any] struct {
+		db      *gorm.DB
+		cursors *query.CursorCodec
+		schema  query.Schema
+	}
+)
+
+func NewRepository[T any](db *gorm.DB, cfg config.Config, schema query.Schema) IRepository[T] {
+	return &Repository[T]{
+		db:      db,
+		cursors: query.NewCursorCodec(cfg.Pagination.CursorSecret),
+		schema:  schema,
+	}
+}
+
+// Find returns one page of rows and its pagination, ready for SetData("pagination", ...).
+// A spec the schema rejects returns a *query.Error.
+func (repo Repository[T]) Find(ctx context.Context, spec query.Spec) ([]T, response.Pagination, error) {
+	spec, err := repo.schema.Normalize(spec)
+	if err != nil {
+		return nil, response.Pagination{}, err
+	}
+
+	filters := repo.filters(spec.Filters)
+	pagination := response.Pagination{PerPage: spec.PerPage}
+
+	if !spec.SkipCount {
+		var total int64
+		if err := instance.Conn(ctx, repo.db).Model(new(T)).Scopes(filters).Count(&total).Error; err != nil {
+			return nil, response.Pagination{}, err
+		}
+		totalRows, totalPages := int(total), int((total+int64(spec.PerPage)-1)/int64(spec.PerPage))
+		pagination.Total, pagination.TotalPages = &totalRows, &totalPages
+	}
+
+	if spec.Mode == query.PageMode {
+		pagination.Page = spec.Page
+
+		// One extra row tells whether there is a next page without counting
+		var rows []T
+		if err := instance.Conn(ctx, repo.db).Scopes(filters, repo.order(spec.Sort)).
+			Offset((spec.Page - 1) * spec.PerPage).Limit(spec.PerPage + 1).Find(&rows).Error; err != nil {
+			return nil, response.Pagination{}, err
+		}
+		if len(rows) > spec.PerPage {
+			rows = rows[:spec.PerPage]
+			pagination.HasNext = true
+		}
+		return rows, pagination, nil
+	}
+
+	rows, err := repo.findCursor(ctx, spec, filters, &pagination)
+	if err != nil {
+		return nil, response.Pagination{}, err
+	}
+	return rows, pagination, nil
+}
+
+// findCursor reads the page after a next cursor or before a prev cursor. The rows before a cursor
+// are read in the reversed sort and put back in order, so both directions use the same keyset condition.
+func (repo Repository[T]) findCursor(ctx context.Context, spec query.Spec, filters func(*gorm.DB) *gorm.DB, pagination *response.Pagination) ([]T, error) {
+	direction := query.Next
+	sort := spec.Sort
+	db := instance.Conn(ctx, repo.db).Scopes(filters)
+
+	if spec.Cursor != "" {
+		var rawValues []string
+		var err error
+		if direction, rawValues, err = repo.cursors.Decode(spec.Sort, spec.Cursor); err != nil {
+			return nil, err
+		}
+		if direction == query.Prev {
+			sort = reverse(sort)
+		}
+
+		after, err := repo.after(sort, rawValues)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where(after)
+	}
+
+	var rows []T
+	if err := db.Scopes(repo.order(sort)).Limit(spec.PerPage + 1).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	more := len(rows) > spec.PerPage
+	if more {
+		rows = rows[:spec.PerPage]
+	}
+	if direction == query.Prev {
+		slices.Reverse(rows)
+	}
+	if len(rows) == 0 {
+		return rows, nil
+	}
+
+	// Coming back from a later page means there is a next page, coming from a cursor at all means there is a previous one
+	hasNext := (direction == query.Next && more) || direction == query.Prev
+	hasPrev := (direction == query.Prev && more) || (direction == query.Next && spec.Cursor != "")
+
+	var err error
+	if hasNext {
+		if pagination.NextCursor, err = repo.cursor(spec.Sort, query.Next, rows[len(rows)-1]); err != nil {
+			return nil, err
+		}
+	}
+	if hasPrev {
+		if pagination.PrevCursor, err = repo.cursor(spec.Sort, query.Prev, rows[0]); err != nil {
+			return nil, err
+		}
+	}
+	pagination.HasNext = hasNext
+
+	return rows, nil
+}
+
+// order is a scope sorting by the fields of the sort
+func (repo Repository[T]) order(sort []query.Sort) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, order := range sort {
+			db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: repo.schema.Column(order.Field)}, Desc: order.Desc})
+		}
+		return db
+	}
+}
+
+// filters is a scope applying the filters of the spec with AND, the values were checked by Normalize
+func (repo Repository[T]) filters(filters []query.Filter) func(*gorm.DB) *gorm.DB {
+	exprs := make([]clause.Expression, 0, len(filters))
+	for _, filter := range filters {
+		column := clause.Column{Name: repo.schema.Column(filter.Field)}
+		values := make([]any, 0, len(filter.Values))
+		for _, raw := range filter.Values {
+			value, _ := repo.schema.Value(filter.Field, raw)
+			values = append(values, value)
+		}
+
+		switch filter.Operator {
+		case query.OpEq:
+			exprs = append(exprs, clause.Eq{Column: column, Value: values[0]})
+		case query.OpNe:
+			exprs = append(exprs, clause.Neq{Column: column, Value: values[0]})
+		case query.OpGt:
+			exprs = append(exprs, clause.Gt{Column: column, Value: values[0]})
+		case query.OpGte:
+			exprs = append(exprs, clause.Gte{Column: column, Value: values[0]})
+		case query.OpLt:
+			exprs = append(exprs, clause.Lt{Column: column, Value: values[0]})
+		case query.OpLte:
+			exprs = append(exprs, clause.Lte{Column: column, Value: values[0]})
+		case query.OpLike:
+			// The value matches anywhere, wildcards in it are escaped so they match literally
+			pattern := "%" + likeEscaper.Replace(fmt.Sprint(values[0])) + "%"
+			exprs = append(exprs, clause.Expr{SQL: "? LIKE ? ESCAPE '!'", Vars: []any{column, pattern}})
+		case query.OpIn:
+			exprs = append(exprs, clause.IN{Column: column, Values: values})
+		}
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		for _, expr := range exprs {
+			db = db.Where(expr)
+		}
+		return db
+	}
+}
+
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// after selects the rows that come after the cursor values in the sort order:
+// (a > x) OR (a = x AND b > y) OR ..., with < for descending fields
+func (repo Repository[T]) after(sort []query.Sort, rawValues []string) (clause.Expression, error) {
+	values := make([]any, len(sort))
+	for i, order := range sort {
+		var err error
+		if values[i], err = repo.schema.Value(order.Field, rawValues[i]); err != nil {
+			return nil, &query.Error{Field: "cursor", Reason: "is invalid or was made for another sort"}
+		}
+	}
+
+	or := make([]clause.Expression, 0, len(sort))
+	for i, order := range sort {
+		and := make([]clause.Expression, 0, i+1)
+		for j, prev := range sort[:i] {
+			and = append(and, clause.Eq{Column: clause.Column{Name: repo.schema.Column(prev.Field)}, Value: values[j]})
+		}
+
+		column := clause.Column{Name: repo.schema.Column(order.Field)}
+		if order.Desc {
+			and = append(and, clause.Lt{Column: column, Value: values[i]})
+		} else {
+			and = append(and, clause.Gt{Column: column, Value: values[i]})
+		}
+		or = append(or, clause.And(and...))
+	}
+	return clause.Or(or...), nil
+}
+
+// cursor reads the sort fields of the row through the gorm schema of T
+func (repo Repository[T]) cursor(sort []query.Sort, direction query.Direction, row T) (string, error) {
+	stmt := &gorm.Statement{DB: repo.db}
+	if err := stmt.Parse(&row); err != nil {
+		return "", err
+	}
+
+	rowValue := reflect.ValueOf(row)
+	values := make([]string, 0, len(sort))
+	for _, order := range sort {
+		field := stmt.Schema.LookUpField(repo.schema.Column(order.Field))
+		if field == nil {
+			return "", fmt.Errorf("sort field %s is not a column of %s", order.Field, stmt.Schema.Name)
+		}
+		value, _ := field.ValueOf(context.Background(), rowValue)
+		values = append(values, formatValue(value))
+	}
+	return repo.cursors.Encode(sort, direction, values), nil
+}
+
+// reverse flips every field of the sort, the rows before a cursor are the rows after it in the reversed sort
+func reverse(sort []query.Sort) []query.Sort {
+	reversed := make([]query.Sort, len(sort))
+	for i, order := range sort {
+		reversed[i] = query.Sort{Field: order.Field, Desc: !order.Desc}
+	}
+	return reversed
+}
+
+// formatValue writes a value the way query.Schema.Value reads it back
+func formatValue(value any) string {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		return t.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v.Interface())
+}
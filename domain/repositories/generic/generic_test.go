@@ -0,0 +1,161 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/domain/models/response"
+	"go.risoftinc.com/xarch/utils/query"
+	"go.risoftinc.com/xarch/utils/testdb"
+)
+
+type item struct {
+	ID    uint `gorm:"primaryKey"`
+	Name  string
+	Price float64
+}
+
+var itemSchema = query.Schema{
+	Fields: map[string]query.Field{
+		"id":    {Type: query.Uint, Sortable: true},
+		"name":  {Type: query.String, Sortable: true},
+		"price": {Type: query.Float, Sortable: true},
+	},
+	DefaultSort: []query.Sort{{Field: "id"}},
+}
+
+// newTestRepository stores item-1 to item-7 priced 10, 20, 20, 30, 30, 40, 40 and a free 100%_off
+func newTestRepository(t *testing.T) IRepository[item] {
+	t.Helper()
+
+	db := testdb.Open(t)
+
+	if err := db.AutoMigrate(&item{}); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	for i := 1; i <= 7; i++ {
+		db.Create(&item{Name: fmt.Sprintf("item-%d", i), Price: float64(i/2*10 + 10)})
+	}
+	db.Create(&item{Name: "100%_off", Price: 0})
+
+	return NewRepository[item](db, config.Config{}, itemSchema)
+}
+
+func names(items []item) []string {
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	return names
+}
+
+func TestFindPage(t *testing.T) {
+	repo := newTestRepository(t)
+
+	items, pagination, err := repo.Find(context.Background(), query.Spec{
+		Filters: []query.Filter{{Field: "price", Operator: query.OpGte, Values: []string{"20"}}},
+		Sort:    []query.Sort{{Field: "price", Desc: true}},
+		Page:    2,
+		PerPage: 2,
+	})
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if got := fmt.Sprint(names(items)); got != "[item-4 item-5]" {
+		t.Errorf("Find() = %s, want [item-4 item-5]", got)
+	}
+	if pagination.Page != 2 || pagination.PerPage != 2 || pagination.Total == nil || *pagination.Total != 6 || *pagination.TotalPages != 3 || !pagination.HasNext {
+		t.Errorf("pagination = %+v, want page 2 of 3 with 6 items", pagination)
+	}
+
+	_, pagination, err = repo.Find(context.Background(), query.Spec{Page: 4, PerPage: 2, SkipCount: true})
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if pagination.Total != nil || pagination.TotalPages != nil || pagination.HasNext {
+		t.Errorf("pagination without count = %+v, want no total and no next page", pagination)
+	}
+}
+
+func TestFindFilters(t *testing.T) {
+	repo := newTestRepository(t)
+
+	tests := []struct {
+		filter query.Filter
+		want   string
+	}{
+		{query.Filter{Field: "id", Operator: query.OpIn, Values: []string{"2", "4"}}, "[item-2 item-4]"},
+		{query.Filter{Field: "name", Operator: query.OpNe, Values: []string{"item-1"}}, "[item-2 item-3 item-4 item-5 item-6 item-7 100%_off]"},
+		{query.Filter{Field: "name", Operator: query.OpLike, Values: []string{"%_"}}, "[100%_off]"},
+		{query.Filter{Field: "price", Operator: query.OpLt, Values: []string{"20"}}, "[item-1 100%_off]"},
+	}
+
+	for _, tt := range tests {
+		items, _, err := repo.Find(context.Background(), query.Spec{Filters: []query.Filter{tt.filter}})
+		if err != nil {
+			t.Fatalf("Find(%+v) error = %v", tt.filter, err)
+		}
+		if got := fmt.Sprint(names(items)); got != tt.want {
+			t.Errorf("Find(%+v) = %s, want %s", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestFindCursor(t *testing.T) {
+	repo := newTestRepository(t)
+
+	spec := query.Spec{
+		Filters:   []query.Filter{{Field: "price", Operator: query.OpGt, Values: []string{"0"}}},
+		Sort:      []query.Sort{{Field: "price", Desc: true}},
+		Mode:      query.CursorMode,
+		PerPage:   3,
+		SkipCount: true,
+	}
+
+	// Walk forward with the next cursors, then back from the last page with the prev cursors
+	var pages []string
+	var last response.Pagination
+	for range 3 {
+		items, pagination, err := repo.Find(context.Background(), spec)
+		if err != nil {
+			t.Fatalf("Find() error = %v", err)
+		}
+		pages = append(pages, fmt.Sprint(names(items)))
+		last = pagination
+		spec.Cursor = pagination.NextCursor
+	}
+	if last.NextCursor != "" || last.HasNext || last.PrevCursor == "" || last.Total != nil {
+		t.Errorf("last page pagination = %+v, want only a prev cursor", last)
+	}
+
+	spec.Cursor = last.PrevCursor
+	for range 2 {
+		items, pagination, err := repo.Find(context.Background(), spec)
+		if err != nil {
+			t.Fatalf("Find() error = %v", err)
+		}
+		pages = append(pages, fmt.Sprint(names(items)))
+		if pagination.NextCursor == "" {
+			t.Errorf("pagination = %+v, want a next cursor when going back", pagination)
+		}
+		last = pagination
+		spec.Cursor = pagination.PrevCursor
+	}
+	if last.PrevCursor != "" {
+		t.Errorf("first page pagination = %+v, want no prev cursor", last)
+	}
+
+	// Equal prices keep the order of their IDs across the pages
+	want := "[[item-6 item-7 item-4] [item-5 item-2 item-3] [item-1] [item-5 item-2 item-3] [item-6 item-7 item-4]]"
+	if got := fmt.Sprint(pages); got != want {
+		t.Errorf("pages = %s, want %s", got, want)
+	}
+
+	spec.Cursor = last.NextCursor
+	spec.Sort = []query.Sort{{Field: "name"}}
+	if _, _, err := repo.Find(context.Background(), spec); err == nil {
+		t.Error("Find() with a cursor of another sort error = nil")
+	}
+}
@@ -9,7 +9,7 @@ import (
 
 type (
 	IHealthRepositories interface {
-		DatabaseHealth(ctx context.Context) (sql.DBStats, error)
+		DatabaseStats(ctx context.Context) (sql.DBStats, error)
 	}
 	HealthRepositories struct {
 		db *gorm.DB
@@ -22,8 +22,12 @@ func NewHealthRepositories(db *gorm.DB) IHealthRepositories {
 	}
 }
 
-func (repo HealthRepositories) DatabaseHealth(ctx context.Context) (sql.DBStats, error) {
-	sqlDB, _ := repo.db.DB()
+// DatabaseStats returns the connection pool statistics, reachability is checked by the health registry
+func (repo HealthRepositories) DatabaseStats(ctx context.Context) (sql.DBStats, error) {
+	sqlDB, err := repo.db.DB()
+	if err != nil {
+		return sql.DBStats{}, err
+	}
 
-	return sqlDB.Stats(), sqlDB.Ping()
+	return sqlDB.Stats(), nil
 }
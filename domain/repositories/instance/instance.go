@@ -13,6 +13,7 @@ type (
 		BeginTransaction(ctx context.Context) (*gorm.DB, error)
 		BeginTransactionWithContext(ctx context.Context) (context.Context, *gorm.DB, error)
 		GetTransactionFromContext(ctx context.Context) (*gorm.DB, bool)
+		WithTransaction(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error
 	}
 
 	InstanceRepository struct {
@@ -21,6 +22,8 @@ type (
 )
 
 func NewInstanceRepository(db *gorm.DB) IInstanceRepository {
+	registerRetryableCallback(db)
+
 	return &InstanceRepository{
 		db: db,
 	}
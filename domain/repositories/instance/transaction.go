@@ -0,0 +1,210 @@
+package instance
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"go.risoftinc.com/xarch/constant"
+	"go.risoftinc.com/xarch/utils/errclass"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+const (
+	// DefaultMaxAttempts is how often a transaction runs when it keeps failing on serialization or deadlock errors
+	DefaultMaxAttempts = 3
+
+	retryBaseDelay       = 20 * time.Millisecond
+	retryableCallback    = "instance:retryable"
+	savepointDepthKey    = ctxKey("savepoint-depth")
+	transactionStateKey  = ctxKey("transaction-state")
+	savepointNamePattern = "sp%d"
+	primaryKey           = ctxKey("primary")
+)
+
+type (
+	ctxKey string
+
+	// TxOption changes how WithTransaction starts a transaction, nested calls ignore it
+	TxOption func(*txOptions)
+
+	txOptions struct {
+		isolation   sql.IsolationLevel
+		readOnly    bool
+		maxAttempts int
+	}
+
+	// TxError reports that the transaction itself could not begin or commit, as opposed to an error of fn
+	TxError struct {
+		Op  string
+		Err error
+	}
+
+	// txState is shared by the statements of one attempt, so a retryable error is noticed
+	// even when the service wrapped it before returning
+	txState struct {
+		retryable bool
+	}
+)
+
+func (e *TxError) Error() string {
+	return fmt.Sprintf("failed to %s transaction: %v", e.Op, e.Err)
+}
+
+func (e *TxError) Unwrap() error {
+	return e.Err
+}
+
+// WithIsolation sets the isolation level, e.g. sql.LevelSerializable
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(o *txOptions) { o.isolation = level }
+}
+
+// WithReadOnly starts a read-only transaction
+func WithReadOnly() TxOption {
+	return func(o *txOptions) { o.readOnly = true }
+}
+
+// WithMaxAttempts sets how often the transaction runs on retryable errors, 1 disables retries
+func WithMaxAttempts(attempts int) TxOption {
+	return func(o *txOptions) { o.maxAttempts = max(attempts, 1) }
+}
+
+// Conn returns the transaction of the context, or db when there is none. Every repository reads through it
+// so its queries join the transaction the service started. Outside a transaction reads go to a read replica
+// when replicas are configured, unless the context was marked with WithPrimary.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(constant.TransactionKey).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	if UsePrimary(ctx) {
+		return db.WithContext(ctx).Clauses(dbresolver.Write)
+	}
+	return db.WithContext(ctx)
+}
+
+// WithPrimary sends the reads of the context to the primary, for read-after-write paths that cannot
+// tolerate replication lag
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey, true)
+}
+
+// UsePrimary reports whether the context was marked with WithPrimary
+func UsePrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey).(bool)
+	return primary
+}
+
+// WithTransaction runs fn in a transaction carried by the context. It commits when fn succeeds and rolls back
+// when fn fails or panics. Inside another transaction it uses a savepoint, so only the work of fn is undone.
+// Serialization failures and deadlocks run fn again in a new transaction, so fn must not have other side effects.
+func (r InstanceRepository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error {
+	if tx, ok := r.GetTransactionFromContext(ctx); ok {
+		return r.savepoint(ctx, tx, fn)
+	}
+
+	options := txOptions{maxAttempts: DefaultMaxAttempts}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	for attempt := 1; ; attempt++ {
+		state := &txState{}
+		err := r.transaction(ctx, state, options, fn)
+		if err == nil || attempt >= options.maxAttempts || !(state.retryable || IsRetryable(err)) {
+			return err
+		}
+
+		// Exponential backoff with jitter, so the transactions that collided do not collide again
+		delay := retryBaseDelay << (attempt - 1)
+		delay += rand.N(delay)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (r InstanceRepository) transaction(ctx context.Context, state *txState, options txOptions, fn func(ctx context.Context) error) error {
+	tx := r.db.WithContext(ctx).Begin(&sql.TxOptions{Isolation: options.isolation, ReadOnly: options.readOnly})
+	if tx.Error != nil {
+		return &TxError{Op: "begin", Err: tx.Error}
+	}
+
+	txCtx := context.WithValue(context.WithValue(ctx, constant.TransactionKey, tx), transactionStateKey, state)
+
+	// The rollback errors are dropped, the transaction is over either way and the caller needs the error of fn
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(txCtx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return &TxError{Op: "commit", Err: err}
+	}
+	return nil
+}
+
+func (r InstanceRepository) savepoint(ctx context.Context, tx *gorm.DB, fn func(ctx context.Context) error) error {
+	depth, _ := ctx.Value(savepointDepthKey).(int)
+	depth++
+	name := fmt.Sprintf(savepointNamePattern, depth)
+
+	if err := tx.SavePoint(name).Error; err != nil {
+		return &TxError{Op: "create savepoint in", Err: err}
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.RollbackTo(name)
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, savepointDepthKey, depth)); err != nil {
+		tx.RollbackTo(name)
+		return err
+	}
+	return nil
+}
+
+// IsRetryable reports serialization failures and deadlocks, which succeed when the transaction runs again
+func IsRetryable(err error) bool {
+	return errclass.Is(err, errclass.Retryable)
+}
+
+// registerRetryableCallback marks the attempt of a statement that failed with a retryable error
+func registerRetryableCallback(db *gorm.DB) {
+	mark := func(db *gorm.DB) {
+		if db.Error == nil || !IsRetryable(db.Error) {
+			return
+		}
+		if state, ok := db.Statement.Context.Value(transactionStateKey).(*txState); ok {
+			state.retryable = true
+		}
+	}
+
+	callbacks := db.Callback()
+	if callbacks.Query().Get(retryableCallback) != nil {
+		return
+	}
+
+	// Registering only fails on duplicate names, which the check above rules out
+	_ = callbacks.Create().After("*").Register(retryableCallback, mark)
+	_ = callbacks.Query().After("*").Register(retryableCallback, mark)
+	_ = callbacks.Update().After("*").Register(retryableCallback, mark)
+	_ = callbacks.Delete().After("*").Register(retryableCallback, mark)
+	_ = callbacks.Row().After("*").Register(retryableCallback, mark)
+	_ = callbacks.Raw().After("*").Register(retryableCallback, mark)
+}
@@ -0,0 +1,133 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.risoftinc.com/xarch/utils/testdb"
+	"gorm.io/gorm"
+)
+
+type note struct {
+	ID   uint `gorm:"primaryKey"`
+	Text string
+}
+
+func newTestRepository(t *testing.T) (IInstanceRepository, *gorm.DB) {
+	t.Helper()
+
+	db := testdb.Open(t)
+
+	if err := db.AutoMigrate(&note{}); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	return NewInstanceRepository(db), db
+}
+
+func texts(t *testing.T, db *gorm.DB) string {
+	t.Helper()
+
+	var notes []note
+	if err := db.Order("id").Find(&notes).Error; err != nil {
+		t.Fatalf("failed to read notes: %v", err)
+	}
+	texts := make([]string, 0, len(notes))
+	for _, n := range notes {
+		texts = append(texts, n.Text)
+	}
+	return fmt.Sprint(texts)
+}
+
+func TestWithTransactionNestedSavepoint(t *testing.T) {
+	repo, db := newTestRepository(t)
+	errInner := errors.New("inner failed")
+
+	err := repo.WithTransaction(context.Background(), func(ctx context.Context) error {
+		if err := Conn(ctx, db).Create(&note{Text: "outer"}).Error; err != nil {
+			return err
+		}
+
+		err := repo.WithTransaction(ctx, func(ctx context.Context) error {
+			Conn(ctx, db).Create(&note{Text: "inner"})
+			return errInner
+		})
+		if !errors.Is(err, errInner) {
+			t.Errorf("nested WithTransaction() error = %v, want %v", err, errInner)
+		}
+
+		return repo.WithTransaction(ctx, func(ctx context.Context) error {
+			return Conn(ctx, db).Create(&note{Text: "second inner"}).Error
+		})
+	})
+	if err != nil {
+		t.Fatalf("WithTransaction() error = %v", err)
+	}
+
+	if got := texts(t, db); got != "[outer second inner]" {
+		t.Errorf("notes = %s, want only the work of the failed savepoint undone", got)
+	}
+}
+
+func TestWithTransactionRollsBackOnPanic(t *testing.T) {
+	repo, db := newTestRepository(t)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("WithTransaction() swallowed the panic")
+			}
+		}()
+		_ = repo.WithTransaction(context.Background(), func(ctx context.Context) error {
+			Conn(ctx, db).Create(&note{Text: "lost"})
+			panic("boom")
+		})
+	}()
+
+	// The single connection is free again only if the transaction was rolled back
+	if got := texts(t, db); got != "[]" {
+		t.Errorf("notes = %s, want none", got)
+	}
+}
+
+func TestWithTransactionRetries(t *testing.T) {
+	repo, db := newTestRepository(t)
+	serialization := &pgconn.PgError{Code: "40001"}
+
+	attempts := 0
+	err := repo.WithTransaction(context.Background(), func(ctx context.Context) error {
+		attempts++
+		Conn(ctx, db).Create(&note{Text: fmt.Sprintf("attempt %d", attempts)})
+		if attempts < 2 {
+			// Services wrap repository errors, the cause stays reachable for errors.As
+			return fmt.Errorf("update failed: %w", serialization)
+		}
+		return nil
+	})
+	if err != nil || attempts != 2 {
+		t.Fatalf("WithTransaction() = %v after %d attempts, want success on the second", err, attempts)
+	}
+	if got := texts(t, db); got != "[attempt 2]" {
+		t.Errorf("notes = %s, want only the committed attempt", got)
+	}
+
+	attempts = 0
+	err = repo.WithTransaction(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return serialization
+	}, WithMaxAttempts(1))
+	if err == nil || attempts != 1 {
+		t.Errorf("WithTransaction() without retries = %v after %d attempts", err, attempts)
+	}
+
+	attempts = 0
+	_ = repo.WithTransaction(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return errors.New("not retryable")
+	})
+	if attempts != 1 {
+		t.Errorf("attempts for a non-retryable error = %d, want 1", attempts)
+	}
+}
@@ -0,0 +1,87 @@
+package user
+
+import (
+	"context"
+
+	"go.risoftinc.com/xarch/config"
+	authModels "go.risoftinc.com/xarch/domain/models/auth"
+	"go.risoftinc.com/xarch/domain/models/response"
+	userModels "go.risoftinc.com/xarch/domain/models/user"
+	"go.risoftinc.com/xarch/domain/repositories/generic"
+	"go.risoftinc.com/xarch/domain/repositories/instance"
+	"go.risoftinc.com/xarch/utils/query"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	IUserRepositories interface {
+		List(ctx context.Context, spec query.Spec) ([]userModels.User, response.Pagination, error)
+		FindByID(ctx context.Context, id uint) (*userModels.User, error)
+		FindByIDForUpdate(ctx context.Context, id uint) (*userModels.User, error)
+		FindByUsername(ctx context.Context, username string) (*userModels.User, error)
+		Create(ctx context.Context, user *userModels.User) error
+		Update(ctx context.Context, user *userModels.User) error
+		Delete(ctx context.Context, id uint) error
+	}
+	UserRepositories struct {
+		db   *gorm.DB
+		list generic.IRepository[userModels.User]
+	}
+)
+
+func NewUserRepositories(db *gorm.DB, cfg config.Config) IUserRepositories {
+	return &UserRepositories{
+		db:   db,
+		list: generic.NewRepository[userModels.User](db, cfg, userModels.QuerySchema),
+	}
+}
+
+// List returns one page of users filtered and sorted by userModels.QuerySchema
+func (repo UserRepositories) List(ctx context.Context, spec query.Spec) ([]userModels.User, response.Pagination, error) {
+	return repo.list.Find(ctx, spec)
+}
+
+func (repo UserRepositories) FindByID(ctx context.Context, id uint) (*userModels.User, error) {
+	var user userModels.User
+	if err := instance.Conn(ctx, repo.db).First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindByIDForUpdate locks the row until the transaction ends, SQLite ignores the lock
+func (repo UserRepositories) FindByIDForUpdate(ctx context.Context, id uint) (*userModels.User, error) {
+	var user userModels.User
+	if err := instance.Conn(ctx, repo.db).Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (repo UserRepositories) FindByUsername(ctx context.Context, username string) (*userModels.User, error) {
+	var user userModels.User
+	if err := instance.Conn(ctx, repo.db).Where("username = ?", username).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (repo UserRepositories) Create(ctx context.Context, user *userModels.User) error {
+	return instance.Conn(ctx, repo.db).Create(user).Error
+}
+
+func (repo UserRepositories) Update(ctx context.Context, user *userModels.User) error {
+	return instance.Conn(ctx, repo.db).Model(user).
+		Select("username", "password", "roles", "salary", "updated_by", "updated_at").
+		Updates(user).Error
+}
+
+// Delete removes the user and its refresh tokens, so sessions of a deleted user cannot be refreshed
+func (repo UserRepositories) Delete(ctx context.Context, id uint) error {
+	conn := instance.Conn(ctx, repo.db)
+	if err := conn.Where("user_id = ?", id).Delete(&authModels.RefreshToken{}).Error; err != nil {
+		return err
+	}
+	return conn.Delete(&userModels.User{}, id).Error
+}
@@ -0,0 +1,262 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/goresponse"
+	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/constant"
+	authModels "go.risoftinc.com/xarch/domain/models/auth"
+	authRepositories "go.risoftinc.com/xarch/domain/repositories/auth"
+	"go.risoftinc.com/xarch/utils/bcrypt"
+	"go.risoftinc.com/xarch/utils/errclass"
+	"go.risoftinc.com/xarch/utils/token"
+)
+
+// dummyPasswordHash is compared when the username does not exist, so unknown users take as long as wrong passwords
+const dummyPasswordHash = "$2a$10$CqRJaUxheMCaRtocArRlAuePEH5wz21am/aAYbE5AjApAGi5zef/W"
+
+type (
+	IAuthServices interface {
+		Login(ctx context.Context, req authModels.LoginRequest) (*authModels.TokenPair, error)
+		Refresh(ctx context.Context, req authModels.RefreshRequest) (*authModels.TokenPair, error)
+		Logout(ctx context.Context, claims *token.Claims, req authModels.LogoutRequest) error
+		IsRevoked(ctx context.Context, claims *token.Claims) (bool, error)
+	}
+	AuthServices struct {
+		logger               gologger.Logger
+		cfg                  config.AuthConfig
+		signer               token.ISigner
+		authRepositories     authRepositories.IAuthRepositories
+		denylistRepositories authRepositories.IDenylistRepositories
+	}
+)
+
+func NewAuthService(
+	logger gologger.Logger,
+	cfg config.Config,
+	signer token.ISigner,
+	authRepositories authRepositories.IAuthRepositories,
+	denylistRepositories authRepositories.IDenylistRepositories,
+) IAuthServices {
+	return &AuthServices{
+		logger:               logger,
+		cfg:                  cfg.Auth,
+		signer:               signer,
+		authRepositories:     authRepositories,
+		denylistRepositories: denylistRepositories,
+	}
+}
+
+// Login verifies the credentials and starts a new refresh token family
+func (svc AuthServices) Login(ctx context.Context, req authModels.LoginRequest) (*authModels.TokenPair, error) {
+	user, err := svc.authRepositories.FindUserByUsername(ctx, req.Username)
+	if err != nil && !errclass.Is(err, errclass.NotFound) {
+		svc.logger.WithContext(ctx).Error("Failed to find user").ErrorData(err).Send()
+		return nil, svc.databaseError(ctx, err)
+	}
+
+	hash := dummyPasswordHash
+	if user != nil {
+		hash = user.Password
+	}
+	if err := bcrypt.VerifyPassword(hash, req.Password); err != nil || user == nil {
+		svc.logger.WithContext(ctx).Warn("Rejected login").Data("username", req.Username).Send()
+		return nil, svc.error(ctx, constant.ErrorInvalidCredentials, errors.New("invalid username or password"))
+	}
+
+	refreshToken, record, err := svc.newRefreshToken(user.ID, uuid.NewString())
+	if err != nil {
+		return nil, svc.error(ctx, constant.ErrorInternalServer, err)
+	}
+	if err := svc.authRepositories.CreateRefreshToken(ctx, record); err != nil {
+		svc.logger.WithContext(ctx).Error("Failed to store refresh token").ErrorData(err).Send()
+		return nil, svc.databaseError(ctx, err)
+	}
+
+	svc.logger.WithContext(ctx).Info("User logged in").Data("user_id", user.ID).Send()
+	return svc.tokenPair(ctx, user, refreshToken)
+}
+
+// Refresh rotates the refresh token. A token that was already rotated or revoked means it was stolen
+// or replayed, the whole family is revoked so neither the thief nor the user can keep refreshing.
+func (svc AuthServices) Refresh(ctx context.Context, req authModels.RefreshRequest) (*authModels.TokenPair, error) {
+	used, err := svc.authRepositories.FindRefreshToken(ctx, hashToken(req.RefreshToken))
+	if errclass.Is(err, errclass.NotFound) {
+		return nil, svc.error(ctx, constant.ErrorTokenInvalid, errors.New("unknown refresh token"))
+	}
+	if err != nil {
+		svc.logger.WithContext(ctx).Error("Failed to find refresh token").ErrorData(err).Send()
+		return nil, svc.databaseError(ctx, err)
+	}
+
+	if used.Used() {
+		return nil, svc.revokeReusedFamily(ctx, used)
+	}
+	if time.Now().After(used.ExpiresAt) {
+		return nil, svc.error(ctx, constant.ErrorTokenExpired, errors.New("refresh token has expired"))
+	}
+
+	// Roles are read again so a refresh picks up role changes
+	user, err := svc.authRepositories.FindUserByID(ctx, used.UserID)
+	if errclass.Is(err, errclass.NotFound) {
+		return nil, svc.error(ctx, constant.ErrorTokenInvalid, errors.New("user of the refresh token no longer exists"))
+	}
+	if err != nil {
+		svc.logger.WithContext(ctx).Error("Failed to find user").ErrorData(err).Send()
+		return nil, svc.databaseError(ctx, err)
+	}
+
+	refreshToken, next, err := svc.newRefreshToken(user.ID, used.FamilyID)
+	if err != nil {
+		return nil, svc.error(ctx, constant.ErrorInternalServer, err)
+	}
+	if err := svc.authRepositories.RotateRefreshToken(ctx, used, next); err != nil {
+		if errors.Is(err, authRepositories.ErrRefreshTokenUsed) {
+			return nil, svc.revokeReusedFamily(ctx, used)
+		}
+		svc.logger.WithContext(ctx).Error("Failed to rotate refresh token").ErrorData(err).Send()
+		return nil, svc.databaseError(ctx, err)
+	}
+
+	return svc.tokenPair(ctx, user, refreshToken)
+}
+
+// Logout denylists the access token until it expires and revokes the family of the refresh token if given
+func (svc AuthServices) Logout(ctx context.Context, claims *token.Claims, req authModels.LogoutRequest) error {
+	if claims.ID != "" && claims.ExpiresAt != nil {
+		if err := svc.denylistRepositories.Revoke(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+			svc.logger.WithContext(ctx).Error("Failed to revoke access token").ErrorData(err).Send()
+			return svc.databaseError(ctx, err)
+		}
+	}
+
+	if req.RefreshToken != "" {
+		refreshToken, err := svc.authRepositories.FindRefreshToken(ctx, hashToken(req.RefreshToken))
+		switch {
+		case errclass.Is(err, errclass.NotFound):
+			// Unknown tokens are ignored, logging out twice is not an error
+		case err != nil:
+			svc.logger.WithContext(ctx).Error("Failed to find refresh token").ErrorData(err).Send()
+			return svc.databaseError(ctx, err)
+		case strconv.FormatUint(uint64(refreshToken.UserID), 10) != claims.Subject:
+			return svc.error(ctx, constant.ErrorTokenInvalid, errors.New("refresh token belongs to another user"))
+		default:
+			if err := svc.authRepositories.RevokeRefreshTokenFamily(ctx, refreshToken.FamilyID); err != nil {
+				svc.logger.WithContext(ctx).Error("Failed to revoke refresh tokens").ErrorData(err).Send()
+				return svc.databaseError(ctx, err)
+			}
+		}
+	}
+
+	svc.logger.WithContext(ctx).Info("User logged out").Data("user_id", claims.Subject).Send()
+	return nil
+}
+
+// IsRevoked reports whether the access token was denylisted by a logout
+func (svc AuthServices) IsRevoked(ctx context.Context, claims *token.Claims) (bool, error) {
+	if claims.ID == "" {
+		return false, nil
+	}
+	return svc.denylistRepositories.IsRevoked(ctx, claims.ID)
+}
+
+func (svc AuthServices) revokeReusedFamily(ctx context.Context, used *authModels.RefreshToken) error {
+	svc.logger.WithContext(ctx).Warn("Refresh token reused, revoking its family").
+		Data("user_id", used.UserID).
+		Data("family_id", used.FamilyID).
+		Send()
+
+	if err := svc.authRepositories.RevokeRefreshTokenFamily(ctx, used.FamilyID); err != nil {
+		svc.logger.WithContext(ctx).Error("Failed to revoke refresh tokens").ErrorData(err).Send()
+		return svc.databaseError(ctx, err)
+	}
+
+	return svc.error(ctx, constant.ErrorTokenRevoked, errors.New("refresh token was already used"))
+}
+
+func (svc AuthServices) tokenPair(ctx context.Context, user *authModels.User, refreshToken string) (*authModels.TokenPair, error) {
+	now := time.Now()
+	claims := &token.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   strconv.FormatUint(uint64(user.ID), 10),
+			Issuer:    svc.cfg.Issuer,
+			Audience:  jwt.ClaimStrings{svc.cfg.Audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(svc.cfg.AccessTokenTTL)),
+		},
+		Username: user.Username,
+		Roles:    splitRoles(user.Roles),
+	}
+
+	accessToken, err := svc.signer.Sign(claims)
+	if err != nil {
+		svc.logger.WithContext(ctx).Error("Failed to sign access token").ErrorData(err).Send()
+		return nil, svc.error(ctx, constant.ErrorInternalServer, err)
+	}
+
+	return &authModels.TokenPair{
+		AccessToken:      accessToken,
+		TokenType:        authModels.TokenTypeBearer,
+		ExpiresIn:        int64(svc.cfg.AccessTokenTTL.Seconds()),
+		RefreshToken:     refreshToken,
+		RefreshExpiresIn: int64(svc.cfg.RefreshTokenTTL.Seconds()),
+	}, nil
+}
+
+// newRefreshToken returns an opaque random token and the record holding its hash
+func (svc AuthServices) newRefreshToken(userID uint, familyID string) (string, *authModels.RefreshToken, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, err
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(raw)
+
+	return refreshToken, &authModels.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: time.Now().Add(svc.cfg.RefreshTokenTTL),
+	}, nil
+}
+
+// databaseError answers a failed repository call, a timeout or an unreachable database keeps its own key
+func (svc AuthServices) databaseError(ctx context.Context, err error) error {
+	return svc.error(ctx, errclass.Key(err, constant.ErrorDatabase), err)
+}
+
+func (svc AuthServices) error(ctx context.Context, key string, err error) error {
+	return goresponse.NewResponseBuilder(key).
+		WithContext(ctx).
+		SetError(err).
+		ToError()
+}
+
+func hashToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// splitRoles splits the comma separated users.roles column
+func splitRoles(roles string) []string {
+	var result []string
+	for _, role := range strings.Split(roles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			result = append(result, role)
+		}
+	}
+	return result
+}
@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/xarch/config"
+	authModels "go.risoftinc.com/xarch/domain/models/auth"
+	authRepositories "go.risoftinc.com/xarch/domain/repositories/auth"
+	"go.risoftinc.com/xarch/utils/bcrypt"
+	"go.risoftinc.com/xarch/utils/token"
+	"gorm.io/gorm"
+)
+
+type fakeAuthRepositories struct {
+	users  map[string]*authModels.User
+	tokens []*authModels.RefreshToken
+}
+
+func (repo *fakeAuthRepositories) FindUserByUsername(ctx context.Context, username string) (*authModels.User, error) {
+	if user, ok := repo.users[username]; ok {
+		return user, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (repo *fakeAuthRepositories) FindUserByID(ctx context.Context, id uint) (*authModels.User, error) {
+	for _, user := range repo.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (repo *fakeAuthRepositories) CreateRefreshToken(ctx context.Context, t *authModels.RefreshToken) error {
+	t.ID = uint(len(repo.tokens) + 1)
+	repo.tokens = append(repo.tokens, t)
+	return nil
+}
+
+func (repo *fakeAuthRepositories) FindRefreshToken(ctx context.Context, tokenHash string) (*authModels.RefreshToken, error) {
+	for _, t := range repo.tokens {
+		if t.TokenHash == tokenHash {
+			copied := *t
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (repo *fakeAuthRepositories) RotateRefreshToken(ctx context.Context, used *authModels.RefreshToken, next *authModels.RefreshToken) error {
+	stored := repo.tokens[used.ID-1]
+	if stored.Used() {
+		return authRepositories.ErrRefreshTokenUsed
+	}
+	_ = repo.CreateRefreshToken(ctx, next)
+	stored.ReplacedBy = &next.ID
+	return nil
+}
+
+func (repo *fakeAuthRepositories) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	now := time.Now()
+	for _, t := range repo.tokens {
+		if t.FamilyID == familyID && t.RevokedAt == nil {
+			t.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+type fakeDenylistRepositories map[string]time.Time
+
+func (repo fakeDenylistRepositories) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	repo[jti] = expiresAt
+	return nil
+}
+
+func (repo fakeDenylistRepositories) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	_, ok := repo[jti]
+	return ok, nil
+}
+
+func newTestAuthService(t *testing.T) (IAuthServices, *fakeAuthRepositories, *token.Verifier) {
+	t.Helper()
+
+	hash, err := bcrypt.HashPasswordWithCost("secret", 4)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	repo := &fakeAuthRepositories{users: map[string]*authModels.User{
+		"jane": {ID: 7, Username: "jane", Password: hash, Roles: "admin, employee"},
+	}}
+
+	cfg := config.Config{Auth: config.AuthConfig{
+		Secret:          "0123456789abcdef0123456789abcdef",
+		Issuer:          "xarch",
+		Audience:        "xarch",
+		AccessTokenTTL:  time.Minute,
+		RefreshTokenTTL: time.Hour,
+	}}
+	verifier, err := token.NewVerifier(token.Options{Secret: cfg.Auth.Secret, Issuer: "xarch", Audience: "xarch"})
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+
+	return NewAuthService(gologger.Logger{}, cfg, verifier, repo, fakeDenylistRepositories{}), repo, verifier
+}
+
+func TestLogin(t *testing.T) {
+	svc, _, verifier := newTestAuthService(t)
+
+	tokens, err := svc.Login(context.Background(), authModels.LoginRequest{Username: "jane", Password: "secret"})
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	claims, err := verifier.Verify(tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("Verify() of the access token error = %v", err)
+	}
+	if claims.Subject != "7" || claims.Username != "jane" || len(claims.Roles) != 2 || claims.ID == "" {
+		t.Errorf("access token claims = %+v", claims)
+	}
+
+	for _, req := range []authModels.LoginRequest{
+		{Username: "jane", Password: "wrong"},
+		{Username: "john", Password: "secret"},
+	} {
+		if _, err := svc.Login(context.Background(), req); err == nil {
+			t.Errorf("Login(%q, %q) error = nil, want invalid credentials", req.Username, req.Password)
+		}
+	}
+}
+
+func TestRefreshRotatesAndDetectsReuse(t *testing.T) {
+	svc, repo, _ := newTestAuthService(t)
+	ctx := context.Background()
+
+	login, err := svc.Login(ctx, authModels.LoginRequest{Username: "jane", Password: "secret"})
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	refreshed, err := svc.Refresh(ctx, authModels.RefreshRequest{RefreshToken: login.RefreshToken})
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if refreshed.RefreshToken == login.RefreshToken {
+		t.Fatal("Refresh() did not rotate the refresh token")
+	}
+
+	// Replaying the first token revokes the family, including the token issued by the rotation
+	if _, err := svc.Refresh(ctx, authModels.RefreshRequest{RefreshToken: login.RefreshToken}); err == nil {
+		t.Fatal("Refresh() with a used token error = nil")
+	}
+	for _, stored := range repo.tokens {
+		if stored.RevokedAt == nil {
+			t.Errorf("refresh token %d is not revoked after reuse", stored.ID)
+		}
+	}
+	if _, err := svc.Refresh(ctx, authModels.RefreshRequest{RefreshToken: refreshed.RefreshToken}); err == nil {
+		t.Error("Refresh() with a token of a revoked family error = nil")
+	}
+}
+
+func TestLogoutRevokesAccessToken(t *testing.T) {
+	svc, _, verifier := newTestAuthService(t)
+	ctx := context.Background()
+
+	login, _ := svc.Login(ctx, authModels.LoginRequest{Username: "jane", Password: "secret"})
+	claims, _ := verifier.Verify(login.AccessToken)
+
+	if revoked, _ := svc.IsRevoked(ctx, claims); revoked {
+		t.Fatal("IsRevoked() before logout = true")
+	}
+	if err := svc.Logout(ctx, claims, authModels.LogoutRequest{RefreshToken: login.RefreshToken}); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	if revoked, _ := svc.IsRevoked(ctx, claims); !revoked {
+		t.Error("IsRevoked() after logout = false")
+	}
+	if _, err := svc.Refresh(ctx, authModels.RefreshRequest{RefreshToken: login.RefreshToken}); err == nil {
+		t.Error("Refresh() after logout error = nil")
+	}
+}
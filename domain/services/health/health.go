@@ -2,13 +2,14 @@ package health
 
 import (
 	"context"
-	"strings"
 
 	"go.risoftinc.com/gologger"
 	"go.risoftinc.com/goresponse"
 	"go.risoftinc.com/xarch/constant"
 	healthModels "go.risoftinc.com/xarch/domain/models/health"
 	healthRepositories "go.risoftinc.com/xarch/domain/repositories/health"
+	"go.risoftinc.com/xarch/utils/errclass"
+	"go.risoftinc.com/xarch/utils/healthcheck"
 )
 
 type (
@@ -18,74 +19,66 @@ type (
 	HealthServices struct {
 		logger             gologger.Logger
 		healthRepositories healthRepositories.IHealthRepositories
+		healthRegistry     healthcheck.IRegistry
 	}
 )
 
 func NewHealthService(
 	logger gologger.Logger,
 	healthRepositories healthRepositories.IHealthRepositories,
+	healthRegistry healthcheck.IRegistry,
 ) IHealthServices {
 	return &HealthServices{
 		logger:             logger,
 		healthRepositories: healthRepositories,
+		healthRegistry:     healthRegistry,
 	}
 }
 
+// HealthMetric checks every registered dependency. Only a critical failure is returned as an error,
+// other failures are reported as degraded in the metric.
 func (svc HealthServices) HealthMetric(ctx context.Context) (*healthModels.HealthMetric, error) {
+	report := svc.healthRegistry.Check(ctx)
+
 	metric := &healthModels.HealthMetric{
-		Status: make(map[string]interface{}),
+		Status: report.Status,
+		Checks: report.Checks,
+	}
+
+	databaseStats, err := svc.healthRepositories.DatabaseStats(ctx)
+	if err == nil {
+		metric.DB = databaseStats
+	}
+
+	failures := report.Failures()
+	for _, failure := range failures {
+		svc.logger.WithContext(ctx).Warn("Dependency health check failed").
+			Data("dependency", failure.Name).
+			Data("critical", failure.Critical).
+			Data("latency", failure.Latency).
+			ErrorData(failure.Err).
+			Send()
 	}
 
-	// Check database health
-	databaseHealth, err := svc.healthRepositories.DatabaseHealth(ctx)
-	if err != nil {
-		metric.Status["database"] = "disconnected"
-		svc.logger.WithContext(ctx).Error("Error database health").ErrorData(err).Send()
+	if report.Status == healthcheck.StatusDown {
+		// Failures are ordered critical first
+		err := failures[0].Err
+		svc.logger.WithContext(ctx).Error("Error dependency health").Data("dependency", failures[0].Name).ErrorData(err).Send()
 		return metric, goresponse.NewResponseBuilder(categorizeError(err)).
 			WithContext(ctx).
 			SetError(err).
 			ToError()
 	}
 
-	metric.Status["database"] = "connected"
-	metric.DB = databaseHealth
-
 	return metric, nil
 }
 
+// categorizeError maps a failed dependency to a response key, the detailed reason such as
+// connection_refused when the error carries one
 func categorizeError(err error) string {
-	errMsg := strings.ToLower(err.Error())
-
-	// Connection errors -> 503/UNAVAILABLE
-	if strings.Contains(errMsg, "connection refused") {
-		return constant.ErrorConnectionRefused
-	}
-	if strings.Contains(errMsg, "too many connections") {
-		return constant.ErrorTooManyConnections
-	}
-	if strings.Contains(errMsg, "timeout") {
-		return constant.ErrorConnectionTimeout
+	class := errclass.Classify(err)
+	if class.Reason != "" {
+		return class.Reason
 	}
-	if strings.Contains(errMsg, "no such host") {
-		return constant.ErrorDnsError
-	}
-
-	// Auth errors -> 401/UNAUTHENTICATED
-	if strings.Contains(errMsg, "authentication failed") {
-		return constant.ErrorAuthFailed
-	}
-	if strings.Contains(errMsg, "access denied") {
-		return constant.ErrorAccessDenied
-	}
-
-	// Internal errors -> 500/INTERNAL
-	if strings.Contains(errMsg, "driver") {
-		return constant.ErrorDriverError
-	}
-	if strings.Contains(errMsg, "ssl") || strings.Contains(errMsg, "tls") {
-		return constant.ErrorSslTlsError
-	}
-
-	// Default -> 500/INTERNAL
-	return constant.ErrorInternalServer
+	return class.Key(constant.ErrorInternalServer)
 }
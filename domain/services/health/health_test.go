@@ -0,0 +1,46 @@
+package health
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	mssql "github.com/microsoft/go-mssqldb"
+
+	"go.risoftinc.com/xarch/constant"
+)
+
+func TestCategorizeError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"postgres auth", &pgconn.PgError{Code: "28P01"}, constant.ErrorAuthFailed},
+		{"postgres too many connections", &pgconn.PgError{Code: "53300"}, constant.ErrorTooManyConnections},
+		{"postgres missing database", &pgconn.PgError{Code: "3D000"}, constant.ErrorAccessDenied},
+		{"mysql auth", &mysql.MySQLError{Number: 1045, Message: "Access denied for user 'root'"}, constant.ErrorAuthFailed},
+		{"mysql too many connections", &mysql.MySQLError{Number: 1040}, constant.ErrorTooManyConnections},
+		{"sqlserver login failed", mssql.Error{Number: 18456, Message: "Login failed for user 'sa'."}, constant.ErrorAuthFailed},
+		{"sqlserver database", fmt.Errorf("ping: %w", mssql.Error{Number: 4060}), constant.ErrorAccessDenied},
+		{"dns", &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "db"}}, constant.ErrorDnsError},
+		{"refused", &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, constant.ErrorConnectionRefused},
+		{"deadline", fmt.Errorf("ping: %w", context.DeadlineExceeded), constant.ErrorConnectionTimeout},
+		{"certificate", &tls.CertificateVerificationError{Err: errors.New("unknown authority")}, constant.ErrorSslTlsError},
+		{"message is not matched", errors.New(`relation "driver_logs" does not exist`), constant.ErrorInternalServer},
+		{"unknown", errors.New("boom"), constant.ErrorInternalServer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := categorizeError(tt.err); got != tt.want {
+				t.Errorf("categorizeError(%v) = %s, want %s", tt.err, got, tt.want)
+			}
+		})
+	}
+}
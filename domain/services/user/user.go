@@ -0,0 +1,320 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/goresponse"
+	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/constant"
+	"go.risoftinc.com/xarch/domain/models/response"
+	userModels "go.risoftinc.com/xarch/domain/models/user"
+	"go.risoftinc.com/xarch/domain/repositories/instance"
+	userRepositories "go.risoftinc.com/xarch/domain/repositories/user"
+	"go.risoftinc.com/xarch/utils/bcrypt"
+	"go.risoftinc.com/xarch/utils/errclass"
+	"go.risoftinc.com/xarch/utils/query"
+	"go.risoftinc.com/xarch/utils/rbac"
+	"go.risoftinc.com/xarch/utils/token"
+)
+
+type (
+	IUserServices interface {
+		List(ctx context.Context, spec query.Spec) ([]userModels.User, response.Pagination, error)
+		Get(ctx context.Context, id uint) (*userModels.User, error)
+		Create(ctx context.Context, req userModels.CreateUserRequest) (*userModels.User, error)
+		Update(ctx context.Context, req userModels.UpdateUserRequest) (*userModels.User, error)
+		Delete(ctx context.Context, id uint) (*userModels.User, error)
+	}
+	UserServices struct {
+		logger             gologger.Logger
+		roles              []string
+		instanceRepository instance.IInstanceRepository
+		userRepositories   userRepositories.IUserRepositories
+	}
+)
+
+func NewUserService(
+	logger gologger.Logger,
+	cfg config.Config,
+	instanceRepository instance.IInstanceRepository,
+	userRepositories userRepositories.IUserRepositories,
+) IUserServices {
+	// Users can only be given the roles the access policy knows about
+	roles := make([]string, 0, len(cfg.RBAC.RolePermissions))
+	for role := range cfg.RBAC.RolePermissions {
+		roles = append(roles, rbac.NormalizeRole(role))
+	}
+	slices.Sort(roles)
+
+	return &UserServices{
+		logger:             logger,
+		roles:              roles,
+		instanceRepository: instanceRepository,
+		userRepositories:   userRepositories,
+	}
+}
+
+func (svc UserServices) List(ctx context.Context, spec query.Spec) ([]userModels.User, response.Pagination, error) {
+	users, pagination, err := svc.userRepositories.List(ctx, spec)
+	if err != nil {
+		var queryErr *query.Error
+		if errors.As(err, &queryErr) {
+			return nil, response.Pagination{}, goresponse.NewResponseBuilder(constant.ErrorValidationFailed).
+				WithContext(ctx).
+				SetData("field", queryErr.Field).
+				SetError(err).
+				ToError()
+		}
+
+		svc.logger.WithContext(ctx).Error("Failed to list users").ErrorData(err).Send()
+		return nil, response.Pagination{}, svc.databaseError(ctx, err)
+	}
+
+	return users, pagination, nil
+}
+
+func (svc UserServices) Get(ctx context.Context, id uint) (*userModels.User, error) {
+	user, err := svc.userRepositories.FindByID(ctx, id)
+	if err != nil {
+		return nil, svc.findError(ctx, id, err)
+	}
+	return user, nil
+}
+
+// Create stores a new user with a bcrypt password, created_by is the caller of the request
+func (svc UserServices) Create(ctx context.Context, req userModels.CreateUserRequest) (*userModels.User, error) {
+	actorID, err := svc.actorID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := svc.checkRoles(ctx, req.Roles)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := bcrypt.HashPasswordWithEnvCost(req.Password)
+	if err != nil {
+		return nil, svc.error(ctx, constant.ErrorInternalServer, err)
+	}
+
+	user := &userModels.User{
+		Username:  req.Username,
+		Password:  password,
+		Roles:     roles,
+		Salary:    req.Salary,
+		CreatedBy: actorID,
+		CreatedAt: time.Now(),
+	}
+
+	err = svc.withTransaction(ctx, func(ctx context.Context) error {
+		if err := svc.checkUsername(ctx, 0, req.Username); err != nil {
+			return err
+		}
+		if err := svc.userRepositories.Create(ctx, user); err != nil {
+			if errclass.Is(err, errclass.Conflict) {
+				// Another request took the username after the check
+				return svc.usernameTaken(ctx, req.Username, err)
+			}
+			svc.logger.WithContext(ctx).Error("Failed to create user").ErrorData(err).Send()
+			return svc.databaseError(ctx, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	svc.logger.WithContext(ctx).Info("User created").Data("user_id", user.ID).Data("created_by", actorID).Send()
+	return user, nil
+}
+
+// Update changes the fields set in the request, updated_by is the caller of the request
+func (svc UserServices) Update(ctx context.Context, req userModels.UpdateUserRequest) (*userModels.User, error) {
+	actorID, err := svc.actorID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var roles []string
+	if req.Roles != nil {
+		if roles, err = svc.checkRoles(ctx, req.Roles); err != nil {
+			return nil, err
+		}
+	}
+
+	var user *userModels.User
+	err = svc.withTransaction(ctx, func(ctx context.Context) error {
+		user, err = svc.userRepositories.FindByIDForUpdate(ctx, req.ID)
+		if err != nil {
+			return svc.findError(ctx, req.ID, err)
+		}
+
+		if req.Username != nil && *req.Username != user.Username {
+			if err := svc.checkUsername(ctx, user.ID, *req.Username); err != nil {
+				return err
+			}
+			user.Username = *req.Username
+		}
+		if req.Password != nil {
+			password, err := bcrypt.HashPasswordWithEnvCost(*req.Password)
+			if err != nil {
+				return svc.error(ctx, constant.ErrorInternalServer, err)
+			}
+			user.Password = password
+		}
+		if req.Roles != nil {
+			user.Roles = roles
+		}
+		if req.Salary != nil {
+			user.Salary = *req.Salary
+		}
+
+		now := time.Now()
+		user.UpdatedBy = &actorID
+		user.UpdatedAt = &now
+
+		if err := svc.userRepositories.Update(ctx, user); err != nil {
+			if errclass.Is(err, errclass.Conflict) {
+				return svc.usernameTaken(ctx, user.Username, err)
+			}
+			svc.logger.WithContext(ctx).Error("Failed to update user").ErrorData(err).Send()
+			return svc.databaseError(ctx, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	svc.logger.WithContext(ctx).Info("User updated").Data("user_id", user.ID).Data("updated_by", actorID).Send()
+	return user, nil
+}
+
+// Delete removes the user and its refresh tokens in one transaction
+func (svc UserServices) Delete(ctx context.Context, id uint) (*userModels.User, error) {
+	actorID, err := svc.actorID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *userModels.User
+	err = svc.withTransaction(ctx, func(ctx context.Context) error {
+		user, err = svc.userRepositories.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return svc.findError(ctx, id, err)
+		}
+
+		if err := svc.userRepositories.Delete(ctx, id); err != nil {
+			svc.logger.WithContext(ctx).Error("Failed to delete user").ErrorData(err).Send()
+			return svc.databaseError(ctx, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	svc.logger.WithContext(ctx).Info("User deleted").Data("user_id", id).Data("deleted_by", actorID).Send()
+	return user, nil
+}
+
+// withTransaction runs fn in a transaction the repositories join through the context,
+// a failure to begin or commit the transaction is a database error
+func (svc UserServices) withTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := svc.instanceRepository.WithTransaction(ctx, fn)
+
+	var txErr *instance.TxError
+	if errors.As(err, &txErr) {
+		svc.logger.WithContext(ctx).Error("Failed to " + txErr.Op + " transaction").ErrorData(txErr.Err).Send()
+		return svc.databaseError(ctx, err)
+	}
+	return err
+}
+
+// actorID is the user ID of the verified token, used for created_by and updated_by
+func (svc UserServices) actorID(ctx context.Context) (uint, error) {
+	claims, ok := token.ClaimsFromContext(ctx)
+	if !ok {
+		return 0, svc.error(ctx, constant.ErrorTokenMissing, token.ErrMissing)
+	}
+
+	id, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil {
+		return 0, svc.error(ctx, constant.ErrorTokenInvalid, fmt.Errorf("subject %q is not a user ID", claims.Subject))
+	}
+	return uint(id), nil
+}
+
+// checkRoles rejects roles the access policy does not know and returns them normalized like the policy compares them
+func (svc UserServices) checkRoles(ctx context.Context, roles []string) ([]string, error) {
+	normalized := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if !slices.Contains(svc.roles, rbac.NormalizeRole(role)) {
+			return nil, goresponse.NewResponseBuilder(constant.ErrorValidationFailed).
+				WithContext(ctx).
+				SetData("field", "roles").
+				SetError(fmt.Errorf("unknown role %q", role)).
+				ToError()
+		}
+		normalized = append(normalized, rbac.NormalizeRole(role))
+	}
+	return normalized, nil
+}
+
+// checkUsername rejects a username taken by another user
+func (svc UserServices) checkUsername(ctx context.Context, id uint, username string) error {
+	existing, err := svc.userRepositories.FindByUsername(ctx, username)
+	if errclass.Is(err, errclass.NotFound) {
+		return nil
+	}
+	if err != nil {
+		svc.logger.WithContext(ctx).Error("Failed to find user").ErrorData(err).Send()
+		return svc.databaseError(ctx, err)
+	}
+	if existing.ID == id {
+		return nil
+	}
+
+	return svc.usernameTaken(ctx, username, errors.New("username is already taken"))
+}
+
+func (svc UserServices) usernameTaken(ctx context.Context, username string, err error) error {
+	return goresponse.NewResponseBuilder(constant.ErrorResourceAlreadyExists).
+		WithContext(ctx).
+		SetData("resource", "users").
+		SetData("field", "username").
+		SetData("value", username).
+		SetError(err).
+		ToError()
+}
+
+func (svc UserServices) findError(ctx context.Context, id uint, err error) error {
+	if errclass.Is(err, errclass.NotFound) {
+		return goresponse.NewResponseBuilder(constant.ErrorUserNotFound).
+			WithContext(ctx).
+			SetData("field", "id").
+			SetData("value", id).
+			SetError(err).
+			ToError()
+	}
+
+	svc.logger.WithContext(ctx).Error("Failed to find user").ErrorData(err).Send()
+	return svc.databaseError(ctx, err)
+}
+
+// databaseError answers a failed repository call, a timeout or an unreachable database keeps its own key
+func (svc UserServices) databaseError(ctx context.Context, err error) error {
+	return svc.error(ctx, errclass.Key(err, constant.ErrorDatabase), err)
+}
+
+func (svc UserServices) error(ctx context.Context, key string, err error) error {
+	return goresponse.NewResponseBuilder(key).
+		WithContext(ctx).
+		SetError(err).
+		ToError()
+}
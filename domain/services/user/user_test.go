@@ -0,0 +1,140 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/xarch/config"
+	userModels "go.risoftinc.com/xarch/domain/models/user"
+	"go.risoftinc.com/xarch/domain/repositories/instance"
+	userRepositories "go.risoftinc.com/xarch/domain/repositories/user"
+	"go.risoftinc.com/xarch/utils/query"
+	"go.risoftinc.com/xarch/utils/testdb"
+	"go.risoftinc.com/xarch/utils/token"
+	"gorm.io/gorm"
+)
+
+// newTestService runs the real repositories on an in-memory SQLite database with the schema of the migrations
+func newTestService(t *testing.T) (IUserServices, *gorm.DB) {
+	t.Helper()
+	t.Setenv("HASHING_COST", "4")
+
+	db := testdb.OpenSchema(t)
+
+	cfg := config.Config{RBAC: config.RBACConfig{RolePermissions: map[string][]string{
+		"admin":    {"*"},
+		"employee": {"users:read"},
+	}}}
+	instanceRepository := instance.NewInstanceRepository(db)
+	svc := NewUserService(gologger.Logger{}, cfg, instanceRepository, userRepositories.NewUserRepositories(db, cfg))
+
+	return svc, db
+}
+
+func asUser(id string) context.Context {
+	return token.WithClaims(context.Background(), &token.Claims{Username: "admin", Roles: []string{"admin"}, RegisteredClaims: jwt.RegisteredClaims{Subject: id}})
+}
+
+func TestUserLifecycle(t *testing.T) {
+	svc, db := newTestService(t)
+	ctx := asUser("1")
+
+	created, err := svc.Create(ctx, userModels.CreateUserRequest{
+		Username: "alice",
+		Password: "correct horse",
+		Roles:    []string{"employee"},
+		Salary:   5000000,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if created.CreatedBy != 1 || created.Password == "correct horse" {
+		t.Errorf("created user = %+v, want created_by 1 and a hashed password", created)
+	}
+
+	if _, err := svc.Create(ctx, userModels.CreateUserRequest{Username: "alice", Password: "other password", Roles: []string{"employee"}}); err == nil {
+		t.Error("Create() with a taken username error = nil")
+	}
+	if _, err := svc.Create(ctx, userModels.CreateUserRequest{Username: "bob", Password: "other password", Roles: []string{"root"}}); err == nil {
+		t.Error("Create() with an unknown role error = nil")
+	}
+
+	salary := 6000000.0
+	updated, err := svc.Update(asUser("7"), userModels.UpdateUserRequest{ID: created.ID, Salary: &salary, Roles: []string{"employee", " Admin"}})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if updated.UpdatedBy == nil || *updated.UpdatedBy != 7 || updated.Username != "alice" {
+		t.Errorf("updated user = %+v, want updated_by 7 and an unchanged username", updated)
+	}
+
+	got, err := svc.Get(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	// Roles are stored the way the access policy compares them
+	if got.Salary != salary || len(got.Roles) != 2 || got.Roles[1] != "admin" {
+		t.Errorf("stored user = %+v", got)
+	}
+
+	users, pagination, err := svc.List(ctx, query.Spec{Filters: []query.Filter{{Field: "username", Operator: query.OpLike, Values: []string{"ali"}}}})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(users) != 1 || pagination.Total == nil || *pagination.Total != 1 || pagination.Page != 1 || pagination.PerPage != query.DefaultPerPage || pagination.HasNext {
+		t.Errorf("List() = %d users, pagination %+v", len(users), pagination)
+	}
+	if _, _, err := svc.List(ctx, query.Spec{Filters: []query.Filter{{Field: "password", Operator: query.OpEq, Values: []string{"x"}}}}); err == nil {
+		t.Error("List() filtering on the password error = nil")
+	}
+
+	db.Exec("INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at, created_at) VALUES (?, 'f', 'h', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)", created.ID)
+	if _, err := svc.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	var tokens int64
+	db.Table("refresh_tokens").Count(&tokens)
+	if tokens != 0 {
+		t.Errorf("refresh tokens after delete = %d, want 0", tokens)
+	}
+	if _, err := svc.Get(ctx, created.ID); err == nil {
+		t.Error("Get() after delete error = nil")
+	}
+}
+
+func TestUpdateRollsBackOnConflict(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := asUser("1")
+
+	for _, username := range []string{"alice", "bob"} {
+		if _, err := svc.Create(ctx, userModels.CreateUserRequest{Username: username, Password: "correct horse", Roles: []string{"employee"}}); err != nil {
+			t.Fatalf("Create(%s) error = %v", username, err)
+		}
+	}
+
+	taken := "alice"
+	if _, err := svc.Update(ctx, userModels.UpdateUserRequest{ID: 2, Username: &taken}); err == nil {
+		t.Fatal("Update() to a taken username error = nil")
+	}
+
+	// The connection is free again only if the transaction was rolled back
+	got, err := svc.Get(ctx, 2)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Username != "bob" || got.UpdatedBy != nil {
+		t.Errorf("user after failed update = %+v", got)
+	}
+}
+
+func TestCreateRequiresCaller(t *testing.T) {
+	svc, _ := newTestService(t)
+
+	_, err := svc.Create(context.Background(), userModels.CreateUserRequest{Username: "alice", Password: "correct horse", Roles: []string{"employee"}})
+	if err == nil || errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Create() without claims error = %v, want token error", err)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build cockroach
+
+package driver
+
+import "testing"
+
+// Run with COCKROACH_TEST_DSN="host=localhost user=root dbname=defaultdb port=26257 sslmode=disable" go test -tags cockroach ./driver/,
+// a PostgreSQL DSN works as well since CockroachDB goes through the PostgreSQL dialector
+func TestCockroachIntegration(t *testing.T) {
+	testLockingUpdate(t, integrationDB(t, "cockroach", "COCKROACH_TEST_DSN"))
+}
@@ -0,0 +1,142 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"go.risoftinc.com/xarch/config"
+)
+
+var errConnecting = errors.New("connection not established yet")
+
+type (
+	// ConnectError reports a dependency that could not be reached within the connect policy
+	ConnectError struct {
+		Name     string
+		Attempts int
+		Err      error
+
+		// secrets are masked in the message, driver errors may echo a DSN or URI
+		secrets []string
+	}
+
+	// Connection reports whether a dependency is connected. With CONNECT_START_DEGRADED the driver returns
+	// before connecting and retries in the background, until then HealthCheck fails so readiness stays false.
+	Connection struct {
+		name    string
+		secrets []string
+		cancel  context.CancelFunc
+		done    chan struct{}
+
+		mu       sync.Mutex
+		attempts int
+		err      error
+	}
+)
+
+func (e *ConnectError) Error() string {
+	return fmt.Sprintf("failed to connect to %s after %d attempts: %s", e.Name, e.Attempts, redactError(e.Err, e.secrets...))
+}
+
+func (e *ConnectError) Unwrap() error {
+	return e.Err
+}
+
+// connect runs attempt until it succeeds. Blocking, it gives up after the attempts or deadline of the policy,
+// in start degraded mode it returns at once and retries in the background until it succeeds or Stop is called.
+func connect(ctx context.Context, name string, policy config.ConnectConfig, attempt func(ctx context.Context) error, secrets ...string) (*Connection, error) {
+	c := &Connection{name: name, secrets: secrets, done: make(chan struct{}), err: errConnecting}
+
+	if !policy.StartDegraded {
+		defer close(c.done)
+		c.cancel = func() {}
+
+		if policy.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, policy.Timeout)
+			defer cancel()
+		}
+		if err := c.retry(ctx, policy, attempt); err != nil {
+			return nil, c.Err()
+		}
+		return c, nil
+	}
+
+	ctx, c.cancel = context.WithCancel(ctx)
+	policy.MaxAttempts = 0
+	go func() {
+		defer close(c.done)
+		c.retry(ctx, policy, attempt)
+	}()
+	log.Printf("Connecting to %s in the background", name)
+	return c, nil
+}
+
+func (c *Connection) retry(ctx context.Context, policy config.ConnectConfig, attempt func(ctx context.Context) error) error {
+	backoff := policy.InitialBackoff
+	for {
+		err := attempt(ctx)
+
+		c.mu.Lock()
+		c.attempts++
+		c.err = err
+		attempts := c.attempts
+		c.mu.Unlock()
+
+		if err == nil {
+			if attempts > 1 {
+				log.Printf("Connected to %s after %d attempts", c.name, attempts)
+			}
+			return nil
+		}
+		if policy.MaxAttempts > 0 && attempts >= policy.MaxAttempts {
+			return err
+		}
+
+		// Equal jitter keeps at least half of the backoff, so instances restarted together spread out
+		delay := backoff/2 + rand.N(backoff/2+1)
+		log.Printf("Failed to connect to %s (attempt %d), retrying in %v: %s", c.name, attempts, delay.Round(time.Millisecond), redactError(err, c.secrets...))
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		backoff = min(backoff*2, policy.MaxBackoff)
+	}
+}
+
+// Err is nil once connected, otherwise a *ConnectError with the error of the last attempt
+func (c *Connection) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err == nil {
+		return nil
+	}
+	return &ConnectError{Name: c.name, Attempts: c.attempts, Err: c.err, secrets: c.secrets}
+}
+
+// Stop ends the background retries, the application is shutting down
+func (c *Connection) Stop() {
+	if c == nil {
+		return
+	}
+	c.cancel()
+	<-c.done
+}
+
+// HealthCheck fails with Err until the connection is established, then runs check
+func (c *Connection) HealthCheck(check func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if err := c.Err(); err != nil {
+			return err
+		}
+		return check(ctx)
+	}
+}
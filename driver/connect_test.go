@@ -0,0 +1,95 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.risoftinc.com/xarch/config"
+)
+
+var testPolicy = config.ConnectConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+func TestConnectRetries(t *testing.T) {
+	attempts := 0
+	conn, err := connect(context.Background(), "test", testPolicy, func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	if err != nil || attempts != 3 {
+		t.Fatalf("connect() = %v after %d attempts, want success on the third", err, attempts)
+	}
+	if err := conn.HealthCheck(func(ctx context.Context) error { return nil })(context.Background()); err != nil {
+		t.Errorf("HealthCheck() error = %v", err)
+	}
+}
+
+func TestConnectGivesUp(t *testing.T) {
+	cause := errors.New("password authentication failed for password=hunter2")
+	_, err := connect(context.Background(), "database", testPolicy, func(ctx context.Context) error {
+		return cause
+	}, "hunter2")
+
+	var connectErr *ConnectError
+	if !errors.As(err, &connectErr) || connectErr.Attempts != 3 || !errors.Is(err, cause) {
+		t.Fatalf("connect() error = %v, want a ConnectError after 3 attempts", err)
+	}
+	if strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("connect() error leaks the password: %v", err)
+	}
+
+	policy := testPolicy
+	policy.MaxAttempts, policy.Timeout = 0, 20*time.Millisecond
+	start := time.Now()
+	if _, err := connect(context.Background(), "database", policy, func(ctx context.Context) error { return cause }); err == nil {
+		t.Error("connect() past the deadline error = nil")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("connect() ignored the deadline, returned after %v", elapsed)
+	}
+}
+
+func TestConnectStartDegraded(t *testing.T) {
+	var up atomic.Bool
+	policy := testPolicy
+	policy.StartDegraded = true
+
+	conn, err := connect(context.Background(), "database", policy, func(ctx context.Context) error {
+		if !up.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("connect() error = %v, want the retries in the background", err)
+	}
+	defer conn.Stop()
+
+	check := conn.HealthCheck(func(ctx context.Context) error { return nil })
+	if err := check(context.Background()); err == nil {
+		t.Error("HealthCheck() before connecting error = nil")
+	}
+
+	// The background retries outlast MaxAttempts until the dependency answers
+	time.Sleep(20 * time.Millisecond)
+	up.Store(true)
+	deadline := time.Now().Add(time.Second)
+	for check(context.Background()) != nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("HealthCheck() error = %v after the dependency came up", check(context.Background()))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestConnectDBUnsupportedType(t *testing.T) {
+	if _, _, err := ConnectDB(context.Background(), config.DatabaseConfig{Type: "oracle"}, testPolicy); err == nil {
+		t.Error("ConnectDB() error = nil, want unsupported type")
+	}
+}
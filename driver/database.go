@@ -2,6 +2,7 @@ package driver
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
 	"time"
@@ -9,69 +10,164 @@ import (
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
+	"gorm.io/driver/sqlserver"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 
 	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/constant"
 )
 
-// ConnectDB creates a database connection based on the database type
-func ConnectDB(cfg config.DatabaseConfig) *gorm.DB {
-	defer func() {
-		if r := recover(); r != nil {
-			log.Panic(fmt.Sprint(r))
-		}
-	}()
+// DBOption customizes the gorm configuration of ConnectDB
+type DBOption func(*gorm.Config)
+
+// WithLogger writes the statements of the database and its replicas to l instead of the gorm default logger,
+// DB_DEBUG is then left to l, see NewGormLogger
+func WithLogger(l logger.Interface) DBOption {
+	return func(c *gorm.Config) {
+		c.Logger = l
+	}
+}
+
+// ConnectDB creates a database connection based on the database type, retrying with the connect policy.
+// In start degraded mode it returns a handle at once and connects in the background, see Connection.
+func ConnectDB(ctx context.Context, cfg config.DatabaseConfig, policy config.ConnectConfig, opts ...DBOption) (*gorm.DB, *Connection, error) {
+	if _, err := newDialector(cfg.Type, "", false); err != nil {
+		return nil, nil, err
+	}
+	secrets := []string{cfg.PostgresDB.DBPass, cfg.MySQLDB.DBPass, cfg.SQLServerDB.DBPass, cfg.CockroachDB.DBPass}
 
 	log.Printf("Connecting to %s database", cfg.Type)
 
+	if policy.StartDegraded {
+		db, err := openDB(cfg, true, opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open %s database: %s", cfg.Type, redactError(err, secrets...))
+		}
+		conn, _ := connect(ctx, constant.ComponentDatabase, policy, DatabaseHealthCheck(db), secrets...)
+		return db, conn, nil
+	}
+
 	var db *gorm.DB
-	var err error
+	conn, err := connect(ctx, constant.ComponentDatabase, policy, func(ctx context.Context) (err error) {
+		db, err = openDB(cfg, false, opts...)
+		return err
+	}, secrets...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return db, conn, nil
+}
 
-	switch cfg.Type {
-	case "postgres":
-		db, err = connectPostgres(cfg.PostgresDB)
-	case "mysql":
-		db, err = connectMySQL(cfg.MySQLDB)
-	case "sqlite":
-		db, err = connectSQLite(cfg.SQLiteDB)
-	default:
-		panic(fmt.Sprintf("Unsupported database type: %s", cfg.Type))
+// openDB opens the primary and its replicas. A lazy open does not talk to the servers,
+// the first statement or ping connects.
+func openDB(cfg config.DatabaseConfig, lazy bool, opts ...DBOption) (*gorm.DB, error) {
+	dialector, err := newDialector(cfg.Type, dataSource(cfg), lazy)
+	if err != nil {
+		return nil, err
+	}
+
+	gormConfig := &gorm.Config{DisableAutomaticPing: lazy}
+	for _, opt := range opts {
+		opt(gormConfig)
 	}
+	customLogger := gormConfig.Logger != nil
 
+	db, err := openGorm(dialector, gormConfig)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to connect to %s database: %v", cfg.Type, err))
+		return nil, err
 	}
 
-	if cfg.DBDebug {
-		db = db.Debug()
+	if cfg.Type == "sqlserver" {
+		useSQLServerLocking(db)
 	}
 
 	// Configure connection pool
 	sqlDB, err := db.DB()
 	if err != nil {
-		panic("Failed to get underlying sql.DB")
+		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
 	}
+	configurePool(sqlDB, cfg)
+
+	log.Printf("Database connection pool configured: MaxIdle=%d, MaxOpen=%d, MaxLifetime=%dmin",
+		cfg.DBMaxIdleCon, cfg.DBMaxOpenCon, cfg.DBMaxLifeTime)
+
+	if err := useReplicas(db, cfg, lazy); err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to configure read replicas: %w", err)
+	}
+
+	if cfg.DBDebug && !customLogger {
+		db = db.Debug()
+	}
+
+	return db, nil
+}
 
-	// Set connection pool settings
+// openGorm opens the dialector, a lazy open disables the automatic ping
+func openGorm(dialector gorm.Dialector, gormConfig *gorm.Config) (*gorm.DB, error) {
+	db, err := gorm.Open(dialector, gormConfig)
+	if err != nil {
+		// gorm keeps the pool of a failed ping open, the next attempt would leak it
+		if db != nil {
+			if sqlDB, _ := db.DB(); sqlDB != nil {
+				sqlDB.Close()
+			}
+		}
+		return nil, err
+	}
+	return db, nil
+}
+
+// configurePool applies the pool settings shared by the primary and its replicas
+func configurePool(sqlDB *sql.DB, cfg config.DatabaseConfig) {
 	sqlDB.SetMaxIdleConns(cfg.DBMaxIdleCon)                                  // Maximum number of idle connections
 	sqlDB.SetMaxOpenConns(cfg.DBMaxOpenCon)                                  // Maximum number of open connections
 	sqlDB.SetConnMaxLifetime(time.Duration(cfg.DBMaxLifeTime) * time.Minute) // Maximum lifetime of connections
+}
 
-	log.Printf("Database connection pool configured: MaxIdle=%d, MaxOpen=%d, MaxLifetime=%dmin",
-		cfg.DBMaxIdleCon, cfg.DBMaxOpenCon, cfg.DBMaxLifeTime)
+// newDialector returns the dialector of the database type. A lazy MySQL dialector skips the server
+// version query and assumes a current server. CockroachDB uses the PostgreSQL dialector.
+func newDialector(dbType, dsn string, lazy bool) (gorm.Dialector, error) {
+	switch dbType {
+	case "postgres", "cockroach":
+		return postgres.New(postgres.Config{DSN: dsn, PreferSimpleProtocol: true}), nil
+	case "mysql":
+		return mysql.New(mysql.Config{DSN: dsn, SkipInitializeWithVersion: lazy}), nil
+	case "sqlite":
+		return sqlite.Open(dsn), nil
+	case "sqlserver":
+		return sqlserver.Open(dsn), nil
+	default:
+		return nil, fmt.Errorf("unsupported database type: %s", dbType)
+	}
+}
 
-	return db
+// dataSource returns the DSN of the primary database
+func dataSource(cfg config.DatabaseConfig) string {
+	switch cfg.Type {
+	case "postgres":
+		return postgresDSN(cfg.PostgresDB)
+	case "mysql":
+		return mysqlDSN(cfg.MySQLDB)
+	case "sqlserver":
+		return sqlServerDSN(cfg.SQLServerDB)
+	case "cockroach":
+		return cockroachDSN(cfg.CockroachDB)
+	default:
+		return cfg.SQLiteDB.DBPath
+	}
 }
 
-// connectPostgres creates a PostgreSQL connection
-func connectPostgres(cfg config.PostgresDB) (*gorm.DB, error) {
+// postgresDSN builds a PostgreSQL DSN
+func postgresDSN(cfg config.PostgresDB) string {
 	// Determine SSL mode string
 	sslMode := "disable"
 	if cfg.SSLMode != "" {
 		sslMode = cfg.SSLMode
 	}
 
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s TimeZone=%s",
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s TimeZone=%s",
 		cfg.DBServer,
 		cfg.DBUser,
 		cfg.DBPass,
@@ -80,16 +176,27 @@ func connectPostgres(cfg config.PostgresDB) (*gorm.DB, error) {
 		sslMode,
 		cfg.DBTimeZone,
 	)
+}
 
-	return gorm.Open(postgres.New(postgres.Config{
-		DSN:                  dsn,
-		PreferSimpleProtocol: true,
-	}), &gorm.Config{})
+// cockroachDSN builds a CockroachDB DSN in the PostgreSQL format
+func cockroachDSN(cfg config.CockroachDB) string {
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s",
+		cfg.DBServer,
+		cfg.DBUser,
+		cfg.DBPass,
+		cfg.DBName,
+		cfg.DBPort,
+		cfg.SSLMode,
+	)
+	if cfg.Cluster != "" {
+		dsn += fmt.Sprintf(" options='--cluster=%s'", cfg.Cluster)
+	}
+	return dsn
 }
 
-// connectMySQL creates a MySQL connection
-func connectMySQL(cfg config.MySQLDB) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=%s&parseTime=%t&loc=%s",
+// mysqlDSN builds a MySQL DSN
+func mysqlDSN(cfg config.MySQLDB) string {
+	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=%s&parseTime=%t&loc=%s",
 		cfg.DBUser,
 		cfg.DBPass,
 		cfg.DBServer,
@@ -99,22 +206,17 @@ func connectMySQL(cfg config.MySQLDB) (*gorm.DB, error) {
 		cfg.ParseTime,
 		cfg.Loc,
 	)
-
-	return gorm.Open(mysql.Open(dsn), &gorm.Config{})
 }
 
-// connectSQLite creates a SQLite connection
-func connectSQLite(cfg config.SQLiteDB) (*gorm.DB, error) {
-	return gorm.Open(sqlite.Open(cfg.DBPath), &gorm.Config{})
-}
-
-// CloseDBWithTimeout closes the database connection with a timeout
+// CloseDB closes the database connection and its read replicas with a timeout
 // This function should be used in a defer statement for graceful shutdown
 func CloseDB(db *gorm.DB) {
 	if db == nil {
 		return
 	}
 
+	closeReplicas(Replicas(db))
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -0,0 +1,53 @@
+package driver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.risoftinc.com/goresponse"
+	"gorm.io/gorm"
+)
+
+// DatabaseHealthCheck pings the SQL database
+func DatabaseHealthCheck(db *gorm.DB) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// ReplicaHealthCheck pings a read replica of the SQL database
+func ReplicaHealthCheck(replica Replica) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return replica.DB.PingContext(ctx)
+	}
+}
+
+// RedisHealthCheck pings the Redis server
+func RedisHealthCheck(client *redis.Client) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	}
+}
+
+// MongoDBHealthCheck pings the MongoDB deployment
+func MongoDBHealthCheck(db *mongo.Database) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return db.Client().Ping(ctx, nil)
+	}
+}
+
+// ResponseManagerHealthCheck reports whether response messages are loaded
+func ResponseManagerHealthCheck(manager *goresponse.AsyncConfigManager) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if manager.GetConfig() == nil {
+			return errors.New("response manager has no configuration loaded")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,61 @@
+//go:build sqlserver || cockroach
+
+package driver
+
+import (
+	"os"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// integrationDB opens the database of the DSN in env, the test is skipped when it is empty
+func integrationDB(t *testing.T, dbType, env string) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv(env)
+	if dsn == "" {
+		t.Skipf("%s is empty", env)
+	}
+	dialector, err := newDialector(dbType, dsn, false)
+	if err != nil {
+		t.Fatalf("newDialector() error = %v", err)
+	}
+	db, err := openGorm(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect to %s: %v", dbType, err)
+	}
+	if dbType == "sqlserver" {
+		useSQLServerLocking(db)
+	}
+	t.Cleanup(func() { CloseDB(db) })
+
+	if err := db.AutoMigrate(&note{}); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	t.Cleanup(func() { db.Migrator().DropTable(&note{}) })
+	return db
+}
+
+// testLockingUpdate reads a row FOR UPDATE and changes it in the same transaction
+func testLockingUpdate(t *testing.T, db *gorm.DB) {
+	t.Helper()
+
+	if err := db.Create(&note{Text: "created"}).Error; err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var n note
+		if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).First(&n).Error; err != nil {
+			return err
+		}
+		return tx.Model(&n).Update("text", "updated").Error
+	})
+	if err != nil {
+		t.Fatalf("locking update error = %v", err)
+	}
+	if got := firstText(t, db); got != "updated" {
+		t.Errorf("note = %s, want updated", got)
+	}
+}
@@ -0,0 +1,106 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.risoftinc.com/gologger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"go.risoftinc.com/xarch/config"
+)
+
+// GormLogger writes gorm statements to gologger with the request ID of the statement context.
+// Failed statements are logged at error, slow ones at warn and, with DB_DEBUG, every statement at debug.
+type GormLogger struct {
+	logger        gologger.Logger
+	level         logger.LogLevel
+	slowThreshold time.Duration
+	logParams     bool
+}
+
+// NewGormLogger configures the logger with DB_DEBUG, DB_SLOW_QUERY_THRESHOLD and DB_LOG_PARAMETERS
+func NewGormLogger(appLogger gologger.Logger, cfg config.DatabaseConfig) *GormLogger {
+	level := logger.Warn
+	if cfg.DBDebug {
+		level = logger.Info
+	}
+
+	return &GormLogger{
+		logger:        appLogger,
+		level:         level,
+		slowThreshold: cfg.DBSlowThreshold,
+		logParams:     cfg.DBLogParams,
+	}
+}
+
+// LogMode returns a copy logging at level, db.Debug() asks for logger.Info
+func (l *GormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Info {
+		l.logger.WithContext(ctx).Info(fmt.Sprintf(msg, data...)).Send()
+	}
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Warn {
+		l.logger.WithContext(ctx).Warn(fmt.Sprintf(msg, data...)).Send()
+	}
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Error {
+		l.logger.WithContext(ctx).Error(fmt.Sprintf(msg, data...)).Send()
+	}
+}
+
+// Trace logs a finished statement. A missing record is an answer, not a failure, the services handle it.
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && l.level >= logger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		sql, rows := fc()
+		l.logger.WithContext(ctx).Error("Database statement failed").
+			Data("sql", sql).
+			Data("rows", rows).
+			Data("elapsed", elapsed.String()).
+			ErrorData(err).
+			Send()
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= logger.Warn:
+		sql, rows := fc()
+		l.logger.WithContext(ctx).Warn("Slow database statement").
+			Data("sql", sql).
+			Data("rows", rows).
+			Data("elapsed", elapsed.String()).
+			Data("threshold", l.slowThreshold.String()).
+			Send()
+	case l.level >= logger.Info:
+		sql, rows := fc()
+		l.logger.WithContext(ctx).Debug("Database statement").
+			Data("sql", sql).
+			Data("rows", rows).
+			Data("elapsed", elapsed.String()).
+			Send()
+	}
+}
+
+// ParamsFilter leaves the placeholders in the logged SQL unless DB_LOG_PARAMETERS is set,
+// parameters hold password hashes, tokens and personal data
+func (l *GormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if l.logParams {
+		return sql, params
+	}
+	return sql, nil
+}
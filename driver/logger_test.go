@@ -0,0 +1,74 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.risoftinc.com/gologger"
+	"gorm.io/gorm"
+
+	"go.risoftinc.com/xarch/config"
+)
+
+func newTestGormLogger(cfg config.DatabaseConfig) *GormLogger {
+	return NewGormLogger(gologger.NewLoggerWithConfig(gologger.LoggerConfig{OutputMode: "terminal", LogLevel: "error"}), cfg)
+}
+
+func TestGormLoggerTrace(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.DatabaseConfig
+		elapsed time.Duration
+		err     error
+		logged  bool
+	}{
+		{"fast statement", config.DatabaseConfig{DBSlowThreshold: time.Second}, 0, nil, false},
+		{"slow statement", config.DatabaseConfig{DBSlowThreshold: time.Millisecond}, 10 * time.Millisecond, nil, true},
+		{"slow query log disabled", config.DatabaseConfig{}, 10 * time.Millisecond, nil, false},
+		{"failed statement", config.DatabaseConfig{}, 0, errors.New("syntax error"), true},
+		{"record not found", config.DatabaseConfig{}, 0, gorm.ErrRecordNotFound, false},
+		{"debug", config.DatabaseConfig{DBDebug: true, DBSlowThreshold: time.Second}, 0, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			built := false
+			newTestGormLogger(tt.cfg).Trace(context.Background(), time.Now().Add(-tt.elapsed), func() (string, int64) {
+				built = true
+				return "SELECT 1", 1
+			}, tt.err)
+			if built != tt.logged {
+				t.Errorf("statement logged = %v, want %v", built, tt.logged)
+			}
+		})
+	}
+}
+
+func TestGormLoggerParamsFilter(t *testing.T) {
+	sql, params := newTestGormLogger(config.DatabaseConfig{}).ParamsFilter(context.Background(), "SELECT * FROM users WHERE password = ?", "secret")
+	if sql != "SELECT * FROM users WHERE password = ?" || params != nil {
+		t.Errorf("ParamsFilter() = %s, %v, want the parameters left out", sql, params)
+	}
+
+	_, params = newTestGormLogger(config.DatabaseConfig{DBLogParams: true}).ParamsFilter(context.Background(), "SELECT ?", "admin")
+	if len(params) != 1 || params[0] != "admin" {
+		t.Errorf("ParamsFilter() with DB_LOG_PARAMETERS = %v, want the parameters", params)
+	}
+}
+
+func TestConnectDBWithLogger(t *testing.T) {
+	cfg := config.DatabaseConfig{Type: "sqlite", SQLiteDB: config.SQLiteDB{DBPath: ":memory:"}, DBDebug: true}
+	gormLogger := newTestGormLogger(cfg)
+
+	db, _, err := ConnectDB(context.Background(), cfg, testPolicy, WithLogger(gormLogger))
+	if err != nil {
+		t.Fatalf("ConnectDB() error = %v", err)
+	}
+	t.Cleanup(func() { CloseDB(db) })
+
+	// DB_DEBUG is left to the logger instead of db.Debug()
+	if db.Logger != gormLogger {
+		t.Errorf("db.Logger = %T, want the logger given to ConnectDB", db.Logger)
+	}
+}
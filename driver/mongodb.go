@@ -11,6 +11,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/constant"
 )
 
 // buildMongoURI builds MongoDB URI with authentication if provided
@@ -30,17 +31,12 @@ func buildMongoURI(cfg config.MongoDBConfig) string {
 	return cfg.URI
 }
 
-// ConnectMongoDB creates a MongoDB connection
-func ConnectMongoDB(cfg config.MongoDBConfig) *mongo.Database {
-	defer func() {
-		if r := recover(); r != nil {
-			log.Panic(fmt.Sprint(r))
-		}
-	}()
-
+// ConnectMongoDB creates a MongoDB connection, retrying with the connect policy. Extra options such as
+// a command monitor are applied last. In start degraded mode it returns at once and connects in the background.
+func ConnectMongoDB(ctx context.Context, cfg config.MongoDBConfig, policy config.ConnectConfig, opts ...*options.ClientOptions) (*mongo.Database, *Connection, error) {
 	// Build URI with authentication if provided
 	uri := buildMongoURI(cfg)
-	log.Printf("Connecting to MongoDB at %s", uri)
+	log.Printf("Connecting to MongoDB at %s", config.RedactURI(uri))
 
 	// Set client options
 	clientOptions := options.Client().
@@ -49,25 +45,27 @@ func ConnectMongoDB(cfg config.MongoDBConfig) *mongo.Database {
 		SetMinPoolSize(cfg.MinPoolSize).
 		SetMaxConnIdleTime(cfg.MaxIdleTime)
 
-	// Connect to MongoDB
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
-	defer cancel()
-
-	client, err := mongo.Connect(ctx, clientOptions)
+	// Connect only validates the options, servers are reached in the background and by the ping
+	client, err := mongo.Connect(ctx, append([]*options.ClientOptions{clientOptions}, opts...)...)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to connect to MongoDB: %v", err))
+		return nil, nil, fmt.Errorf("failed to create MongoDB client: %s", redactError(err, cfg.Password))
 	}
 
 	// Ping the database
-	err = client.Ping(ctx, nil)
+	conn, err := connect(ctx, constant.ComponentMongoDB, policy, func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+		return client.Ping(ctx, nil)
+	}, cfg.Password)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to ping MongoDB: %v", err))
+		CloseMongoDB(client)
+		return nil, nil, err
 	}
 
 	log.Printf("MongoDB connection pool configured: MaxPoolSize=%d, MinPoolSize=%d, MaxIdleTime=%v",
 		cfg.MaxPoolSize, cfg.MinPoolSize, cfg.MaxIdleTime)
 
-	return client.Database(cfg.Database)
+	return client.Database(cfg.Database), conn, nil
 }
 
 // CloseMongoDB closes the MongoDB connection
@@ -0,0 +1,17 @@
+package driver
+
+import (
+	"strings"
+)
+
+// redactError returns the error message with every non-empty secret masked,
+// so driver errors that echo a DSN or URI do not leak credentials into logs
+func redactError(err error, secrets ...string) string {
+	message := err.Error()
+	for _, secret := range secrets {
+		if secret != "" {
+			message = strings.ReplaceAll(message, secret, "******")
+		}
+	}
+	return message
+}
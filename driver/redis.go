@@ -9,19 +9,15 @@ import (
 	"github.com/redis/go-redis/v9"
 
 	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/constant"
 )
 
-// ConnectRedis creates a Redis connection
-func ConnectRedis(cfg config.RedisConfig) *redis.Client {
-	defer func() {
-		if r := recover(); r != nil {
-			log.Panic(fmt.Sprint(r))
-		}
-	}()
-
+// ConnectRedis creates a Redis connection, retrying with the connect policy.
+// In start degraded mode it returns the client at once and connects in the background, see Connection.
+func ConnectRedis(ctx context.Context, cfg config.RedisConfig, policy config.ConnectConfig) (*redis.Client, *Connection, error) {
 	log.Printf("Connecting to Redis at %s:%d", cfg.Host, cfg.Port)
 
-	// Create Redis client
+	// Create Redis client, it connects on the first command
 	rdb := redis.NewClient(&redis.Options{
 		Addr:         fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
 		Username:     cfg.Username,
@@ -36,18 +32,20 @@ func ConnectRedis(cfg config.RedisConfig) *redis.Client {
 	})
 
 	// Test connection
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.DialTimeout)
-	defer cancel()
-
-	_, err := rdb.Ping(ctx).Result()
+	conn, err := connect(ctx, constant.ComponentRedis, policy, func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, cfg.DialTimeout)
+		defer cancel()
+		return rdb.Ping(ctx).Err()
+	}, cfg.Password)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to connect to Redis: %v", err))
+		rdb.Close()
+		return nil, nil, err
 	}
 
 	log.Printf("Redis connection pool configured: PoolSize=%d, MinIdleConns=%d, MaxRetries=%d",
 		cfg.PoolSize, cfg.MinIdleConns, cfg.MaxRetries)
 
-	return rdb
+	return rdb, conn, nil
 }
 
 // CloseRedis closes the Redis connection
@@ -0,0 +1,125 @@
+package driver
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/driver/sqlserver"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"go.risoftinc.com/xarch/config"
+)
+
+const replicasPluginName = "xarch:replicas"
+
+type (
+	// Replica is a read replica opened by ConnectDB
+	Replica struct {
+		Name string
+		DB   *sql.DB
+	}
+
+	// replicas keeps the replica pools on the gorm instance, so health checks and CloseDB can reach them
+	replicas struct {
+		list []Replica
+	}
+)
+
+func (p *replicas) Name() string {
+	return replicasPluginName
+}
+
+func (p *replicas) Initialize(*gorm.DB) error {
+	return nil
+}
+
+// Replicas returns the read replicas of db, none when ConnectDB was given no replica DSN
+func Replicas(db *gorm.DB) []Replica {
+	if plugin, ok := db.Config.Plugins[replicasPluginName].(*replicas); ok {
+		return plugin.list
+	}
+	return nil
+}
+
+// useReplicas routes reads to the replica DSNs, writes, locking reads and transactions stay on the primary
+func useReplicas(db *gorm.DB, cfg config.DatabaseConfig, lazy bool) error {
+	if len(cfg.Replicas) == 0 {
+		return nil
+	}
+
+	plugin := &replicas{}
+	dialectors := make([]gorm.Dialector, 0, len(cfg.Replicas))
+	for i, dsn := range cfg.Replicas {
+		sqlDB, err := openReplica(cfg, dsn, lazy)
+		if err != nil {
+			closeReplicas(plugin.list)
+			return fmt.Errorf("failed to connect to replica %s: %w", config.RedactDSN(dsn), err)
+		}
+		plugin.list = append(plugin.list, Replica{Name: fmt.Sprintf("replica-%d", i+1), DB: sqlDB})
+		dialectors = append(dialectors, replicaDialector(cfg.Type, sqlDB))
+	}
+
+	var policy dbresolver.Policy = dbresolver.RandomPolicy{}
+	if cfg.ReplicaPolicy == "round-robin" {
+		policy = dbresolver.StrictRoundRobinPolicy()
+	}
+
+	if err := db.Use(dbresolver.Register(dbresolver.Config{Replicas: dialectors, Policy: policy})); err != nil {
+		closeReplicas(plugin.list)
+		return err
+	}
+	if err := db.Use(plugin); err != nil {
+		closeReplicas(plugin.list)
+		return err
+	}
+
+	log.Printf("Database read replicas configured: Count=%d, Policy=%s", len(plugin.list), cfg.ReplicaPolicy)
+	return nil
+}
+
+// openReplica opens a replica with the dialect and pool settings of the primary
+func openReplica(cfg config.DatabaseConfig, dsn string, lazy bool) (*sql.DB, error) {
+	dialector, err := newDialector(cfg.Type, dsn, lazy)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := openGorm(dialector, &gorm.Config{DisableAutomaticPing: lazy})
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	configurePool(sqlDB, cfg)
+	return sqlDB, nil
+}
+
+// replicaDialector wraps an open replica pool, so the resolver reuses it instead of connecting again.
+// Statements are built by the dialector of the primary, the MySQL version query is not needed.
+func replicaDialector(dbType string, sqlDB *sql.DB) gorm.Dialector {
+	switch dbType {
+	case "mysql":
+		return mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true})
+	case "sqlite":
+		return &sqlite.Dialector{Conn: sqlDB}
+	case "sqlserver":
+		return sqlserver.New(sqlserver.Config{Conn: sqlDB})
+	default:
+		return postgres.New(postgres.Config{Conn: sqlDB, PreferSimpleProtocol: true})
+	}
+}
+
+func closeReplicas(list []Replica) {
+	for _, replica := range list {
+		if err := replica.DB.Close(); err != nil {
+			log.Printf("Failed to close DB %s connection: %v", replica.Name, err)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package driver
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/domain/repositories/instance"
+)
+
+type note struct {
+	ID   uint `gorm:"primaryKey"`
+	Text string
+}
+
+// seedDatabase creates a sqlite file holding one note, so each test can tell which database answered
+func seedDatabase(t *testing.T, path, text string) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	if err := db.AutoMigrate(&note{}); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	if err := db.Create(&note{Text: text}).Error; err != nil {
+		t.Fatalf("failed to seed %s: %v", path, err)
+	}
+}
+
+func firstText(t *testing.T, db *gorm.DB) string {
+	t.Helper()
+
+	var n note
+	if err := db.Order("id").First(&n).Error; err != nil {
+		t.Fatalf("failed to read note: %v", err)
+	}
+	return n.Text
+}
+
+func TestConnectDBReplicas(t *testing.T) {
+	dir := t.TempDir()
+	primary, replica := filepath.Join(dir, "primary.db"), filepath.Join(dir, "replica.db")
+	seedDatabase(t, primary, "primary")
+	seedDatabase(t, replica, "replica")
+
+	ctx := context.Background()
+	db, _, err := ConnectDB(ctx, config.DatabaseConfig{
+		Type:          "sqlite",
+		SQLiteDB:      config.SQLiteDB{DBPath: primary},
+		DBMaxIdleCon:  1,
+		DBMaxOpenCon:  1,
+		Replicas:      []string{replica},
+		ReplicaPolicy: "round-robin",
+	}, testPolicy)
+	if err != nil {
+		t.Fatalf("ConnectDB() error = %v", err)
+	}
+	t.Cleanup(func() { CloseDB(db) })
+
+	if got := firstText(t, db); got != "replica" {
+		t.Errorf("read answered by %s, want replica", got)
+	}
+	if got := firstText(t, instance.Conn(instance.WithPrimary(ctx), db)); got != "primary" {
+		t.Errorf("read with WithPrimary answered by %s, want primary", got)
+	}
+
+	if err := db.Create(&note{Text: "written"}).Error; err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	var count int64
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		return tx.Model(&note{}).Count(&count).Error
+	}); err != nil || count != 2 {
+		t.Errorf("notes read in a transaction = %d, %v, want the write on the primary", count, err)
+	}
+
+	replicas := Replicas(db)
+	if len(replicas) != 1 || replicas[0].Name != "replica-1" {
+		t.Fatalf("Replicas() = %+v, want replica-1", replicas)
+	}
+	if err := ReplicaHealthCheck(replicas[0])(ctx); err != nil {
+		t.Errorf("ReplicaHealthCheck() error = %v", err)
+	}
+}
+
+func TestConnectDBWithoutReplicas(t *testing.T) {
+	db, _, err := ConnectDB(context.Background(), config.DatabaseConfig{Type: "sqlite", SQLiteDB: config.SQLiteDB{DBPath: ":memory:"}}, testPolicy)
+	if err != nil {
+		t.Fatalf("ConnectDB() error = %v", err)
+	}
+	t.Cleanup(func() { CloseDB(db) })
+
+	if replicas := Replicas(db); replicas != nil {
+		t.Errorf("Replicas() = %+v, want none", replicas)
+	}
+}
@@ -0,0 +1,84 @@
+package driver
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.risoftinc.com/xarch/config"
+)
+
+// sqlServerDSN builds a SQL Server DSN in URL form, which escapes the credentials
+func sqlServerDSN(cfg config.SQLServerDB) string {
+	query := url.Values{}
+	query.Set("database", cfg.DBName)
+	query.Set("encrypt", cfg.Encrypt)
+	query.Set("TrustServerCertificate", strconv.FormatBool(cfg.TrustServerCertificate))
+
+	dsn := url.URL{
+		Scheme:   "sqlserver",
+		User:     url.UserPassword(cfg.DBUser, cfg.DBPass),
+		Host:     net.JoinHostPort(cfg.DBServer, strconv.Itoa(cfg.DBPort)),
+		RawQuery: query.Encode(),
+	}
+	return dsn.String()
+}
+
+// useSQLServerLocking writes clause.Locking as table hints, T-SQL has no SELECT ... FOR UPDATE
+func useSQLServerLocking(db *gorm.DB) {
+	db.ClauseBuilders["FOR"] = func(clause.Clause, clause.Builder) {}
+	db.ClauseBuilders["FROM"] = func(c clause.Clause, builder clause.Builder) {
+		stmt, ok := builder.(*gorm.Statement)
+		if !ok {
+			c.Build(builder)
+			return
+		}
+		locking, ok := stmt.Clauses["FOR"].Expression.(clause.Locking)
+		from, isFrom := c.Expression.(clause.From)
+		if !ok || !isFrom {
+			c.Build(builder)
+			return
+		}
+
+		hint := tableHint(locking)
+		tables := from.Tables
+		if len(tables) == 0 {
+			tables = []clause.Table{{Name: clause.CurrentTable}}
+		}
+
+		builder.WriteString("FROM ")
+		for idx, table := range tables {
+			if idx > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteQuoted(table)
+			builder.WriteString(hint)
+		}
+		for _, join := range from.Joins {
+			builder.WriteByte(' ')
+			join.Build(builder)
+		}
+	}
+}
+
+// tableHint translates the strength and options of a locking clause, e.g. FOR UPDATE NOWAIT
+func tableHint(locking clause.Locking) string {
+	hints := []string{"ROWLOCK"}
+	switch strings.ToUpper(locking.Strength) {
+	case clause.LockingStrengthUpdate:
+		hints = append(hints, "UPDLOCK")
+	case clause.LockingStrengthShare:
+		hints = append(hints, "HOLDLOCK")
+	}
+	switch strings.ToUpper(locking.Options) {
+	case clause.LockingOptionsNoWait:
+		hints = append(hints, "NOWAIT")
+	case clause.LockingOptionsSkipLocked:
+		hints = append(hints, "READPAST")
+	}
+	return " WITH (" + strings.Join(hints, ", ") + ")"
+}
@@ -4,21 +4,40 @@
 package grpc
 
 import (
+	"github.com/redis/go-redis/v9"
 	"go.risoftinc.com/elsa"
 	"go.risoftinc.com/gologger"
 	"go.risoftinc.com/goresponse"
 	"go.risoftinc.com/xarch/config"
+	authRepo "go.risoftinc.com/xarch/domain/repositories/auth"
 	healthRepo "go.risoftinc.com/xarch/domain/repositories/health"
+	"go.risoftinc.com/xarch/domain/repositories/instance"
+	userRepo "go.risoftinc.com/xarch/domain/repositories/user"
+	authSvc "go.risoftinc.com/xarch/domain/services/auth"
 	healthSvc "go.risoftinc.com/xarch/domain/services/health"
+	userSvc "go.risoftinc.com/xarch/domain/services/user"
 	entities "go.risoftinc.com/xarch/infrastructure/grpc/entities"
-	healthHandler "go.risoftinc.com/xarch/infrastructure/grpc/handler/health"
+	authHandler "go.risoftinc.com/xarch/infrastructure/grpc/handler/auth"
+	healthHdl "go.risoftinc.com/xarch/infrastructure/grpc/handler/health"
+	userHandler "go.risoftinc.com/xarch/infrastructure/grpc/handler/user"
 	mid "go.risoftinc.com/xarch/infrastructure/grpc/middleware"
+	"go.risoftinc.com/xarch/infrastructure/metrics"
+	"go.risoftinc.com/xarch/infrastructure/tracing"
+	"go.risoftinc.com/xarch/utils/healthcheck"
+	"go.risoftinc.com/xarch/utils/token"
 	"gorm.io/gorm"
 )
 
 type Dependencies struct {
-	Middlewares    mid.IContextMiddleware
-	HealthHandlers healthHandler.HealthHandler
+	Middlewares          mid.IContextMiddleware
+	MetricsMiddleware    mid.IMetricsMiddleware
+	TracingMiddleware    mid.ITracingMiddleware
+	AuthMiddleware       mid.IAuthMiddleware
+	RBACMiddleware       mid.IRBACMiddleware
+	HealthHandlers       healthHdl.HealthHandler
+	HealthStatusHandlers *healthHdl.HealthStatusHandler
+	AuthHandlers         *authHandler.AuthHandler
+	UserHandlers         *userHandler.UserHandler
 }
 
 func InitializeServices(
@@ -26,6 +45,13 @@ func InitializeServices(
 	cfg config.Config,
 	logger gologger.Logger,
 	async *goresponse.AsyncConfigManager,
+	healthRegistry healthcheck.IRegistry,
+	probes healthcheck.IProbes,
+	metrics *metrics.Metrics,
+	tracing *tracing.Tracing,
+	verifier token.IVerifier,
+	signer token.ISigner,
+	redisClient *redis.Client,
 ) *Dependencies {
 	elsa.Generate(
 		RepositorySet,
@@ -40,14 +66,23 @@ func InitializeServices(
 
 var RepositorySet = elsa.Set(
 	healthRepo.NewHealthRepositories,
+	authRepo.NewAuthRepositories,
+	authRepo.NewDenylistRepositories,
+	instance.NewInstanceRepository,
+	userRepo.NewUserRepositories,
 )
 
 var ServicesSet = elsa.Set(
 	healthSvc.NewHealthService,
+	authSvc.NewAuthService,
+	userSvc.NewUserService,
 )
 
 var HandlerSet = elsa.Set(
-	healthHandler.NewHealthHandlers,
+	healthHdl.NewHealthHandlers,
+	healthHdl.NewHealthStatusHandlers,
+	authHandler.NewAuthHandlers,
+	userHandler.NewUserHandlers,
 )
 
 var EntitiesSet = elsa.Set(
@@ -56,4 +91,8 @@ var EntitiesSet = elsa.Set(
 
 var MidlewareSet = elsa.Set(
 	mid.NewContextMiddleware,
+	mid.NewMetricsMiddleware,
+	mid.NewTracingMiddleware,
+	mid.NewAuthMiddleware,
+	mid.NewRBACMiddleware,
 )
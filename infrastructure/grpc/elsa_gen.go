@@ -9,35 +9,74 @@ package grpc
 import (
 	"go.risoftinc.com/elsa"
 
+	authHandler "go.risoftinc.com/xarch/infrastructure/grpc/handler/auth"
+	authRepo "go.risoftinc.com/xarch/domain/repositories/auth"
+	authSvc "go.risoftinc.com/xarch/domain/services/auth"
 	config "go.risoftinc.com/xarch/config"
 	entities "go.risoftinc.com/xarch/infrastructure/grpc/entities"
 	gologger "go.risoftinc.com/gologger"
 	goresponse "go.risoftinc.com/goresponse"
 	gorm "gorm.io/gorm"
-	healthHandler "go.risoftinc.com/xarch/infrastructure/grpc/handler/health"
+	healthHdl "go.risoftinc.com/xarch/infrastructure/grpc/handler/health"
 	healthRepo "go.risoftinc.com/xarch/domain/repositories/health"
 	healthSvc "go.risoftinc.com/xarch/domain/services/health"
+	healthcheck "go.risoftinc.com/xarch/utils/healthcheck"
+	instance "go.risoftinc.com/xarch/domain/repositories/instance"
+	metrics "go.risoftinc.com/xarch/infrastructure/metrics"
 	mid "go.risoftinc.com/xarch/infrastructure/grpc/middleware"
+	redis "github.com/redis/go-redis/v9"
+	token "go.risoftinc.com/xarch/utils/token"
+	tracing "go.risoftinc.com/xarch/infrastructure/tracing"
+	userHandler "go.risoftinc.com/xarch/infrastructure/grpc/handler/user"
+	userRepo "go.risoftinc.com/xarch/domain/repositories/user"
+	userSvc "go.risoftinc.com/xarch/domain/services/user"
 )
 
-// This file generated from dep_manager.go at 2025-09-12T22:31:31+07:00
+// This file generated from dep_manager.go at 2026-10-17T14:05:14+07:00
 
 type Dependencies struct {
-	Middlewares    mid.IContextMiddleware
-	HealthHandlers healthHandler.HealthHandler
+	Middlewares          mid.IContextMiddleware
+	MetricsMiddleware    mid.IMetricsMiddleware
+	TracingMiddleware    mid.ITracingMiddleware
+	AuthMiddleware       mid.IAuthMiddleware
+	RBACMiddleware       mid.IRBACMiddleware
+	HealthHandlers       healthHdl.HealthHandler
+	HealthStatusHandlers *healthHdl.HealthStatusHandler
+	AuthHandlers         *authHandler.AuthHandler
+	UserHandlers         *userHandler.UserHandler
 }
 
-func InitializeServices(db *gorm.DB, cfg config.Config, logger gologger.Logger, async *goresponse.AsyncConfigManager) *Dependencies {
+func InitializeServices(db *gorm.DB, cfg config.Config, logger gologger.Logger, async *goresponse.AsyncConfigManager, healthRegistry healthcheck.IRegistry, probes healthcheck.IProbes, metrics *metrics.Metrics, tracing *tracing.Tracing, verifier token.IVerifier, signer token.ISigner, redisClient *redis.Client) *Dependencies {
 	iHealthRepositories := healthRepo.NewHealthRepositories(db)
-	iHealthServices := healthSvc.NewHealthService(logger, iHealthRepositories)
+	iAuthRepositories := authRepo.NewAuthRepositories(db)
+	iDenylistRepositories := authRepo.NewDenylistRepositories(db, redisClient, cfg)
+	iInstanceRepository := instance.NewInstanceRepository(db)
+	iUserRepositories := userRepo.NewUserRepositories(db, cfg)
+	iHealthServices := healthSvc.NewHealthService(logger, iHealthRepositories, healthRegistry)
+	iAuthServices := authSvc.NewAuthService(logger, cfg, signer, iAuthRepositories, iDenylistRepositories)
+	iUserServices := userSvc.NewUserService(logger, cfg, iInstanceRepository, iUserRepositories)
 	iGrpcEntities := entities.NewGrpcEntities(async)
 	iContextMiddleware := mid.NewContextMiddleware(logger)
-	healthHandler := healthHandler.NewHealthHandlers(logger, iGrpcEntities, iHealthServices)
+	iMetricsMiddleware := mid.NewMetricsMiddleware(metrics)
+	iTracingMiddleware := mid.NewTracingMiddleware(tracing)
+	iAuthMiddleware := mid.NewAuthMiddleware(logger, iGrpcEntities, verifier, iAuthServices, cfg)
+	iRBACMiddleware := mid.NewRBACMiddleware(logger, iGrpcEntities, cfg)
+	healthHandler := healthHdl.NewHealthHandlers(logger, iGrpcEntities, iHealthServices)
+	healthStatusHandler := healthHdl.NewHealthStatusHandlers(logger, cfg, probes)
+	authHandler := authHandler.NewAuthHandlers(logger, iGrpcEntities, iAuthServices)
+	userHandler := userHandler.NewUserHandlers(logger, iGrpcEntities, iUserServices)
 
-	elsa.Generate(iHealthRepositories, iHealthServices, iGrpcEntities, iContextMiddleware, healthHandler)
+	elsa.Generate(iHealthRepositories, iAuthRepositories, iDenylistRepositories, iInstanceRepository, iUserRepositories, iHealthServices, iAuthServices, iUserServices, iGrpcEntities, iContextMiddleware, iMetricsMiddleware, iTracingMiddleware, iAuthMiddleware, iRBACMiddleware, healthHandler, healthStatusHandler, authHandler, userHandler)
 	return &Dependencies{
-		Middlewares:    iContextMiddleware,
-		HealthHandlers: *healthHandler,
+		Middlewares:          iContextMiddleware,
+		MetricsMiddleware:    iMetricsMiddleware,
+		TracingMiddleware:    iTracingMiddleware,
+		AuthMiddleware:       iAuthMiddleware,
+		RBACMiddleware:       iRBACMiddleware,
+		HealthHandlers:       *healthHandler,
+		HealthStatusHandlers: healthStatusHandler,
+		AuthHandlers:         authHandler,
+		UserHandlers:         userHandler,
 	}
 }
 
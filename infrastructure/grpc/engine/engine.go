@@ -4,17 +4,23 @@ import (
 	"context"
 	"fmt"
 	"net"
-	"os"
-	"os/signal"
-	"sync"
-	"syscall"
-	"time"
 
+	"github.com/redis/go-redis/v9"
 	"go.risoftinc.com/gologger"
 	"go.risoftinc.com/goresponse"
 	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/constant"
 	dep "go.risoftinc.com/xarch/infrastructure/grpc"
+	healthHandler "go.risoftinc.com/xarch/infrastructure/grpc/handler/health"
 	"go.risoftinc.com/xarch/infrastructure/grpc/router"
+	"go.risoftinc.com/xarch/infrastructure/lifecycle"
+	"go.risoftinc.com/xarch/infrastructure/metrics"
+	"go.risoftinc.com/xarch/infrastructure/tracing"
+	"go.risoftinc.com/xarch/utils/certificate"
+	"go.risoftinc.com/xarch/utils/healthcheck"
+	"go.risoftinc.com/xarch/utils/token"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"gorm.io/gorm"
 )
 
@@ -23,59 +29,106 @@ type App struct {
 	Logger          gologger.Logger
 	DB              *gorm.DB
 	ResponseManager *goresponse.AsyncConfigManager
+	HealthRegistry  healthcheck.IRegistry
+	Probes          healthcheck.IProbes
+	Metrics         *metrics.Metrics
+	Tracing         *tracing.Tracing
+	Verifier        token.IVerifier
+	Signer          token.ISigner
+	Redis           *redis.Client // connected with REDIS_ENABLED, nil otherwise
 }
 
-func StartGRPC(app App, wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		// Initialize dependencies
-		dependencies := dep.InitializeServices(app.DB, app.Config, app.Logger, app.ResponseManager)
+// Component returns the gRPC server as a lifecycle component.
+// The port is bound on start so address errors are reported before the application is up.
+func Component(app App) lifecycle.Component {
+	var (
+		grpcServer   *grpc.Server
+		listener     net.Listener
+		healthStatus *healthHandler.HealthStatusHandler
+		stopHealth   context.CancelFunc
+	)
+	address := fmt.Sprintf("%s:%d", app.Config.Grpc.Server, app.Config.Grpc.Port)
 
-		// Register services
-		grpcServer := router.RegisterGRPCServices(dependencies)
+	return lifecycle.Component{
+		Name:      constant.ComponentGrpcServer,
+		DependsOn: []string{constant.ComponentDatabase, constant.ComponentResponseManager},
+		Start: func(ctx context.Context) error {
+			// Initialize dependencies
+			dependencies := dep.InitializeServices(app.DB, app.Config, app.Logger, app.ResponseManager, app.HealthRegistry, app.Probes, app.Metrics, app.Tracing, app.Verifier, app.Signer, app.Redis)
 
-		// Start gRPC server in background
-		go func() {
-			// Create listener
-			lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", app.Config.Grpc.Server, app.Config.Grpc.Port))
-			if err != nil {
-				app.Logger.Fatal("Failed to listen on gRPC port: " + err.Error()).Send()
+			// Serve TLS when GRPC_USING_SECURE is set, certificates are reloaded from disk when they change
+			var opts []grpc.ServerOption
+			if app.Config.Grpc.UsingSecure {
+				reloader, err := certificate.NewReloader(tlsOptions(app))
+				if err != nil {
+					return fmt.Errorf("failed to load gRPC TLS certificate: %w", err)
+				}
+				opts = append(opts, grpc.Creds(credentials.NewTLS(reloader.TLSConfig())))
 			}
 
-			app.Logger.Info(fmt.Sprintf("gRPC server starting on %s:%d", app.Config.Grpc.Server, app.Config.Grpc.Port)).Send()
+			// Register services
+			grpcServer = router.RegisterGRPCServices(dependencies, opts...)
 
-			if err := grpcServer.Serve(lis); err != nil {
-				app.Logger.Fatal("Failed to serve gRPC server: " + err.Error()).Send()
+			var err error
+			listener, err = net.Listen("tcp", address)
+			if err != nil {
+				return fmt.Errorf("failed to listen on gRPC port: %w", err)
 			}
-		}()
 
-		// Wait for interrupt signal to gracefully shutdown the server
-		quit := make(chan os.Signal, 1)
-		signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
-		<-quit
+			// Keep grpc.health.v1 statuses in sync with the health service
+			var healthCtx context.Context
+			healthCtx, stopHealth = context.WithCancel(context.Background())
+			healthStatus = dependencies.HealthStatusHandlers
+			go healthStatus.Run(healthCtx)
 
-		app.Logger.Info("Shutdown signal received").Send()
+			return nil
+		},
+		Run: func() error {
+			app.Logger.Info(fmt.Sprintf("gRPC server starting on %s", address)).Send()
+			return grpcServer.Serve(listener)
+		},
+		Stop: func(ctx context.Context) error {
+			// Report readiness NOT_SERVING to probes and Watch streams before draining connections,
+			// liveness is SERVING until the server has stopped
+			stopHealth()
+			healthStatus.Drain()
+			defer healthStatus.Shutdown()
 
-		// Graceful shutdown with timeout
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
+			done := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(done)
+			}()
 
-		// Shutdown gRPC server
-		done := make(chan bool)
-		go func() {
-			grpcServer.GracefulStop()
-			done <- true
-		}()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				app.Logger.Info("gRPC server shutdown timeout, forcing stop").Send()
+				grpcServer.Stop()
+				return ctx.Err()
+			}
+		},
+	}
+}
 
-		select {
-		case <-done:
-			app.Logger.Info("gRPC server shutdown successfully").Send()
-		case <-ctx.Done():
-			app.Logger.Info("gRPC server shutdown timeout, forcing stop").Send()
-			grpcServer.Stop()
-		}
+// tlsOptions maps the gRPC TLS configuration to certificate reloader options
+func tlsOptions(app App) certificate.Options {
+	cfg := app.Config.Grpc.TLS
 
-		app.Logger.Info("gRPC Application shutdown completed").Send()
-	}()
+	return certificate.Options{
+		CertFile:       cfg.CertFile,
+		KeyFile:        cfg.KeyFile,
+		ClientCAFile:   cfg.ClientCAFile,
+		ClientAuth:     cfg.ClientAuth,
+		MinVersion:     cfg.MinVersion,
+		ReloadInterval: cfg.ReloadInterval,
+		OnReload: func(err error) {
+			if err != nil {
+				app.Logger.Error("Failed to reload gRPC TLS certificate, keeping the previous one").ErrorData(err).Send()
+				return
+			}
+			app.Logger.Info("gRPC TLS certificate reloaded").Send()
+		},
+	}
 }
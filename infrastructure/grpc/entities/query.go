@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"go.risoftinc.com/xarch/domain/models/response"
+	pb "go.risoftinc.com/xarch/infrastructure/grpc/proto"
+	"go.risoftinc.com/xarch/utils/query"
+)
+
+// QuerySpec converts the query of a list request, the spec still has to be checked against a schema
+func QuerySpec(req *pb.QuerySpec) query.Spec {
+	spec := query.Spec{
+		Page:      int(req.GetPage()),
+		PerPage:   int(req.GetPerPage()),
+		SkipCount: req.GetSkipCount(),
+	}
+
+	for _, filter := range req.GetFilters() {
+		operator := query.Operator(filter.GetOperator())
+		if operator == "" {
+			operator = query.OpEq
+		}
+		spec.Filters = append(spec.Filters, query.Filter{
+			Field:    filter.GetField(),
+			Operator: operator,
+			Values:   filter.GetValues(),
+		})
+	}
+	for _, sort := range req.GetSort() {
+		spec.Sort = append(spec.Sort, query.Sort{Field: sort.GetField(), Desc: sort.GetDesc()})
+	}
+	if req != nil && req.Cursor != nil {
+		spec.Mode = query.CursorMode
+		spec.Cursor = req.GetCursor()
+	}
+
+	return spec
+}
+
+// Pagination converts the pagination of a list response
+func Pagination(pagination response.Pagination) *pb.Pagination {
+	res := &pb.Pagination{
+		Page:       int32(pagination.Page),
+		PerPage:    int32(pagination.PerPage),
+		NextCursor: pagination.NextCursor,
+		PrevCursor: pagination.PrevCursor,
+		HasNext:    pagination.HasNext,
+	}
+	if pagination.Total != nil {
+		total, totalPages := int32(*pagination.Total), int32(*pagination.TotalPages)
+		res.Total, res.TotalPages = &total, &totalPages
+	}
+	return res
+}
@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/goresponse"
+	"go.risoftinc.com/xarch/constant"
+	authModels "go.risoftinc.com/xarch/domain/models/auth"
+	authServices "go.risoftinc.com/xarch/domain/services/auth"
+	"go.risoftinc.com/xarch/infrastructure/grpc/entities"
+	authpb "go.risoftinc.com/xarch/infrastructure/grpc/proto"
+	"go.risoftinc.com/xarch/utils/grpc"
+	"go.risoftinc.com/xarch/utils/token"
+	"go.risoftinc.com/xarch/utils/validator"
+	"google.golang.org/grpc/status"
+)
+
+type (
+	AuthHandler struct {
+		authpb.UnimplementedAuthServiceServer
+		logger       gologger.Logger
+		grpcEntities entities.IGrpcEntities
+		authServices authServices.IAuthServices
+		validator    *validator.CustomValidator
+	}
+)
+
+func NewAuthHandlers(
+	logger gologger.Logger,
+	grpcEntities entities.IGrpcEntities,
+	authServices authServices.IAuthServices,
+) *AuthHandler {
+	return &AuthHandler{
+		logger:       logger,
+		grpcEntities: grpcEntities,
+		authServices: authServices,
+		validator:    validator.NewCustomValidator(),
+	}
+}
+
+func (handler AuthHandler) Login(ctx context.Context, req *authpb.LoginRequest) (*authpb.TokenResponse, error) {
+	loginReq := authModels.LoginRequest{
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+	}
+	if err := handler.validate(ctx, loginReq); err != nil {
+		return handler.tokenError(err)
+	}
+
+	tokens, err := handler.authServices.Login(ctx, loginReq)
+	if err != nil {
+		return handler.tokenError(err)
+	}
+
+	return handler.tokenResponse(ctx, tokens), nil
+}
+
+func (handler AuthHandler) Refresh(ctx context.Context, req *authpb.RefreshRequest) (*authpb.TokenResponse, error) {
+	refreshReq := authModels.RefreshRequest{
+		RefreshToken: req.GetRefreshToken(),
+	}
+	if err := handler.validate(ctx, refreshReq); err != nil {
+		return handler.tokenError(err)
+	}
+
+	tokens, err := handler.authServices.Refresh(ctx, refreshReq)
+	if err != nil {
+		return handler.tokenError(err)
+	}
+
+	return handler.tokenResponse(ctx, tokens), nil
+}
+
+// Logout requires a token, the auth interceptor puts its claims in the context
+func (handler AuthHandler) Logout(ctx context.Context, req *authpb.LogoutRequest) (*authpb.LogoutResponse, error) {
+	claims, ok := token.ClaimsFromContext(ctx)
+	if !ok {
+		return handler.logoutError(
+			goresponse.NewResponseBuilder(constant.ErrorTokenMissing).
+				WithContext(ctx).
+				SetError(token.ErrMissing).
+				ToError(),
+		)
+	}
+
+	err := handler.authServices.Logout(ctx, claims, authModels.LogoutRequest{
+		RefreshToken: req.GetRefreshToken(),
+	})
+	if err != nil {
+		return handler.logoutError(err)
+	}
+
+	grpcResponse := handler.grpcEntities.ResponseFormater(
+		goresponse.NewResponseBuilder(constant.IsResponseSuccess).WithContext(ctx),
+	)
+
+	return &authpb.LogoutResponse{
+		Meta: &authpb.Meta{
+			Message: grpcResponse.Meta.Message,
+		},
+	}, nil
+}
+
+func (handler AuthHandler) validate(ctx context.Context, req any) error {
+	if err := handler.validator.Validate(req); err != nil {
+		var fields []string
+		var validationErr *validator.ValidationErrors
+		if errors.As(err, &validationErr) {
+			fields = validationErr.Fields()
+		}
+
+		return goresponse.NewResponseBuilder(constant.ErrorValidationFailed).
+			WithContext(ctx).
+			SetData("field", strings.Join(fields, ", ")).
+			SetError(err).
+			ToError()
+	}
+	return nil
+}
+
+func (handler AuthHandler) tokenResponse(ctx context.Context, tokens *authModels.TokenPair) *authpb.TokenResponse {
+	grpcResponse := handler.grpcEntities.ResponseFormater(
+		goresponse.NewResponseBuilder(constant.IsResponseSuccess).WithContext(ctx),
+	)
+
+	return &authpb.TokenResponse{
+		Meta: &authpb.Meta{
+			Message: grpcResponse.Meta.Message,
+		},
+		Data: &authpb.TokenData{
+			AccessToken:      tokens.AccessToken,
+			TokenType:        tokens.TokenType,
+			ExpiresIn:        tokens.ExpiresIn,
+			RefreshToken:     tokens.RefreshToken,
+			RefreshExpiresIn: tokens.RefreshExpiresIn,
+		},
+	}
+}
+
+func (handler AuthHandler) tokenError(err error) (*authpb.TokenResponse, error) {
+	grpcResponse := handler.grpcEntities.ResponseFormaterError(err)
+
+	return &authpb.TokenResponse{
+		Meta: &authpb.Meta{
+			Message: grpcResponse.Meta.Message,
+			Error:   &grpcResponse.Meta.Error,
+		},
+	}, status.Errorf(grpc.IntToCode(grpcResponse.Code), "%s", grpcResponse.Meta.Message)
+}
+
+func (handler AuthHandler) logoutError(err error) (*authpb.LogoutResponse, error) {
+	grpcResponse := handler.grpcEntities.ResponseFormaterError(err)
+
+	return &authpb.LogoutResponse{
+		Meta: &authpb.Meta{
+			Message: grpcResponse.Meta.Message,
+			Error:   &grpcResponse.Meta.Error,
+		},
+	}, status.Errorf(grpc.IntToCode(grpcResponse.Code), "%s", grpcResponse.Meta.Message)
+}
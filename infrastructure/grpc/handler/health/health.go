@@ -2,6 +2,7 @@ package health
 
 import (
 	"context"
+	"time"
 
 	"go.risoftinc.com/gologger"
 	"go.risoftinc.com/goresponse"
@@ -54,11 +55,17 @@ func (handler HealthHandler) GetHealthMetric(ctx context.Context, req *healthpb.
 
 	// Convert health metric to protobuf format
 	statusMap := make(map[string]string)
-	for k, v := range metric.Status {
-		if str, ok := v.(string); ok {
-			statusMap[k] = str
-		} else {
-			statusMap[k] = "unknown"
+	checks := make(map[string]*healthpb.DependencyCheck)
+	for name, result := range metric.Checks {
+		statusMap[name] = result.Status
+		checks[name] = &healthpb.DependencyCheck{
+			Status:    result.Status,
+			Critical:  result.Critical,
+			Latency:   result.Latency,
+			CheckedAt: result.CheckedAt.Format(time.RFC3339Nano),
+		}
+		if result.LastError != "" {
+			checks[name].LastError = &result.LastError
 		}
 	}
 
@@ -82,7 +89,9 @@ func (handler HealthHandler) GetHealthMetric(ctx context.Context, req *healthpb.
 			Error:   errorPtr, // Will be nil for success, so field won't appear in JSON
 		},
 		Data: &healthpb.HealthMetricData{
-			Status: statusMap,
+			Status:  statusMap,
+			Overall: metric.Status,
+			Checks:  checks,
 			Database: &healthpb.DatabaseInfo{
 				MaxOpenConnections: int32(metric.DB.MaxOpenConnections),
 				OpenConnections:    int32(metric.DB.OpenConnections),
@@ -0,0 +1,121 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/xarch/config"
+	healthpb "go.risoftinc.com/xarch/infrastructure/grpc/proto"
+	"go.risoftinc.com/xarch/utils/healthcheck"
+	grpchealth "google.golang.org/grpc/health"
+	grpchealthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Probe service names, e.g. grpc_health_probe -service=readiness
+const (
+	LivenessService  = "liveness"
+	ReadinessService = "readiness"
+	StartupService   = "startup"
+)
+
+type (
+	// HealthStatusHandler serves the standard grpc.health.v1.Health service used by
+	// Kubernetes probes, Envoy and grpc_health_probe. Check, List and Watch come from
+	// the embedded server, the statuses mirror the HTTP /livez, /readyz and /startupz probes.
+	HealthStatusHandler struct {
+		*grpchealth.Server
+		logger   gologger.Logger
+		probes   healthcheck.IProbes
+		interval time.Duration
+	}
+)
+
+func NewHealthStatusHandlers(
+	logger gologger.Logger,
+	cfg config.Config,
+	probes healthcheck.IProbes,
+) *HealthStatusHandler {
+	server := grpchealth.NewServer()
+	// Report NOT_SERVING until the application has started
+	for _, service := range append(readinessServices(), StartupService) {
+		server.SetServingStatus(service, grpchealthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	server.SetServingStatus(LivenessService, grpchealthpb.HealthCheckResponse_SERVING)
+
+	handler := &HealthStatusHandler{
+		Server:   server,
+		logger:   logger,
+		probes:   probes,
+		interval: cfg.Grpc.HealthCheckInterval,
+	}
+
+	// Startup and draining are applied immediately instead of on the next tick
+	probes.Subscribe(func() {
+		handler.Refresh(context.Background())
+	})
+
+	return handler
+}
+
+// readinessServices lists the overall server ("") and every service whose status follows readiness
+func readinessServices() []string {
+	return []string{
+		"",
+		ReadinessService,
+		healthpb.HealthService_ServiceDesc.ServiceName,
+	}
+}
+
+// Run refreshes the serving status every interval until ctx is cancelled.
+// Watch streams receive an update whenever the status flips.
+func (handler *HealthStatusHandler) Run(ctx context.Context) {
+	ticker := time.NewTicker(handler.interval)
+	defer ticker.Stop()
+
+	current := handler.Refresh(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			status := handler.Refresh(ctx)
+			if status != current {
+				handler.logger.WithContext(ctx).Warn("gRPC health status changed").
+					Data("from", current.String()).
+					Data("to", status.String()).
+					Send()
+				current = status
+			}
+		}
+	}
+}
+
+// Refresh applies the startup and readiness probes and returns the readiness status.
+// Only a critical dependency failure is NOT_SERVING, a degraded application keeps serving.
+func (handler *HealthStatusHandler) Refresh(ctx context.Context) grpchealthpb.HealthCheckResponse_ServingStatus {
+	handler.SetServingStatus(StartupService, servingStatus(handler.probes.Started()))
+
+	ready, _ := handler.probes.Ready(ctx)
+	status := servingStatus(ready)
+	for _, service := range readinessServices() {
+		handler.SetServingStatus(service, status)
+	}
+
+	return status
+}
+
+// Drain reports the readiness services NOT_SERVING while the server drains its connections.
+// Liveness stays SERVING, a failing liveness probe would restart the instance in the middle of the drain.
+func (handler *HealthStatusHandler) Drain() {
+	for _, service := range readinessServices() {
+		handler.SetServingStatus(service, grpchealthpb.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+func servingStatus(ok bool) grpchealthpb.HealthCheckResponse_ServingStatus {
+	if ok {
+		return grpchealthpb.HealthCheckResponse_SERVING
+	}
+	return grpchealthpb.HealthCheckResponse_NOT_SERVING
+}
@@ -0,0 +1,104 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/xarch/config"
+	healthpb "go.risoftinc.com/xarch/infrastructure/grpc/proto"
+	"go.risoftinc.com/xarch/utils/healthcheck"
+	grpchealthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealthStatusHandlerMirrorsProbes(t *testing.T) {
+	var dbErr error
+	registry := healthcheck.NewRegistry(config.HealthConfig{CheckTimeout: time.Second})
+	registry.Register(healthcheck.Checker{Name: "database", Critical: true, Check: func(ctx context.Context) error {
+		return dbErr
+	}})
+	probes := healthcheck.NewProbes(registry)
+
+	cfg := config.Config{Grpc: config.GrpcServer{HealthCheckInterval: time.Second}}
+	handler := NewHealthStatusHandlers(gologger.Logger{}, cfg, probes)
+
+	const (
+		serving    = grpchealthpb.HealthCheckResponse_SERVING
+		notServing = grpchealthpb.HealthCheckResponse_NOT_SERVING
+	)
+
+	tests := []struct {
+		name        string
+		apply       func()
+		wantReady   grpchealthpb.HealthCheckResponse_ServingStatus
+		wantStartup grpchealthpb.HealthCheckResponse_ServingStatus
+	}{
+		{
+			name:        "starting",
+			apply:       func() {},
+			wantReady:   notServing,
+			wantStartup: notServing,
+		},
+		{
+			name:        "started",
+			apply:       probes.MarkStarted,
+			wantReady:   serving,
+			wantStartup: serving,
+		},
+		{
+			name: "database unreachable",
+			apply: func() {
+				dbErr = errors.New("connection refused")
+				handler.Refresh(context.Background())
+			},
+			wantReady:   notServing,
+			wantStartup: serving,
+		},
+		{
+			name: "database recovered",
+			apply: func() {
+				dbErr = nil
+				handler.Refresh(context.Background())
+			},
+			wantReady:   serving,
+			wantStartup: serving,
+		},
+		{
+			name:        "draining",
+			apply:       probes.MarkDraining,
+			wantReady:   notServing,
+			wantStartup: serving,
+		},
+		{
+			name:        "stopping",
+			apply:       handler.Drain,
+			wantReady:   notServing,
+			wantStartup: serving,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.apply()
+
+			want := map[string]grpchealthpb.HealthCheckResponse_ServingStatus{
+				"":               tt.wantReady,
+				ReadinessService: tt.wantReady,
+				healthpb.HealthService_ServiceDesc.ServiceName: tt.wantReady,
+				StartupService:  tt.wantStartup,
+				LivenessService: serving,
+			}
+			for service, wantStatus := range want {
+				resp, err := handler.Check(context.Background(), &grpchealthpb.HealthCheckRequest{Service: service})
+				if err != nil {
+					t.Fatalf("Check(%q) error = %v", service, err)
+				}
+				if resp.Status != wantStatus {
+					t.Errorf("Check(%q) = %v, want %v", service, resp.Status, wantStatus)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/goresponse"
+	"go.risoftinc.com/xarch/constant"
+	userModels "go.risoftinc.com/xarch/domain/models/user"
+	userServices "go.risoftinc.com/xarch/domain/services/user"
+	"go.risoftinc.com/xarch/infrastructure/grpc/entities"
+	userpb "go.risoftinc.com/xarch/infrastructure/grpc/proto"
+	"go.risoftinc.com/xarch/utils/grpc"
+	"go.risoftinc.com/xarch/utils/validator"
+	"google.golang.org/grpc/status"
+)
+
+type (
+	UserHandler struct {
+		userpb.UnimplementedUserServiceServer
+		logger       gologger.Logger
+		grpcEntities entities.IGrpcEntities
+		userServices userServices.IUserServices
+		validator    *validator.CustomValidator
+	}
+)
+
+func NewUserHandlers(
+	logger gologger.Logger,
+	grpcEntities entities.IGrpcEntities,
+	userServices userServices.IUserServices,
+) *UserHandler {
+	return &UserHandler{
+		logger:       logger,
+		grpcEntities: grpcEntities,
+		userServices: userServices,
+		validator:    validator.NewCustomValidator(),
+	}
+}
+
+func (handler UserHandler) ListUsers(ctx context.Context, req *userpb.ListUsersRequest) (*userpb.ListUsersResponse, error) {
+	users, pagination, err := handler.userServices.List(ctx, entities.QuerySpec(req.GetQuery()))
+	if err != nil {
+		return handler.listError(err)
+	}
+
+	grpcResponse := handler.grpcEntities.ResponseFormater(
+		goresponse.NewResponseBuilder(constant.IsResponseDataRetrieved).
+			WithContext(ctx).
+			SetData("count", len(users)),
+	)
+
+	data := make([]*userpb.UserData, 0, len(users))
+	for i := range users {
+		data = append(data, toUserData(&users[i]))
+	}
+
+	return &userpb.ListUsersResponse{
+		Meta: &userpb.Meta{
+			Message: grpcResponse.Meta.Message,
+		},
+		Data:       data,
+		Pagination: entities.Pagination(pagination),
+	}, nil
+}
+
+func (handler UserHandler) GetUser(ctx context.Context, req *userpb.GetUserRequest) (*userpb.UserResponse, error) {
+	user, err := handler.userServices.Get(ctx, uint(req.GetId()))
+	if err != nil {
+		return handler.userError(err)
+	}
+
+	return handler.userResponse(ctx,
+		goresponse.NewResponseBuilder(constant.IsResponseRetrieved).WithContext(ctx),
+		user,
+	), nil
+}
+
+func (handler UserHandler) CreateUser(ctx context.Context, req *userpb.CreateUserRequest) (*userpb.UserResponse, error) {
+	createReq := userModels.CreateUserRequest{
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+		Roles:    req.GetRoles(),
+		Salary:   req.GetSalary(),
+	}
+	if err := handler.validate(ctx, createReq); err != nil {
+		return handler.userError(err)
+	}
+
+	user, err := handler.userServices.Create(ctx, createReq)
+	if err != nil {
+		return handler.userError(err)
+	}
+
+	return handler.userResponse(ctx,
+		goresponse.NewResponseBuilder(constant.IsResponseUserCreated).
+			WithContext(ctx).
+			SetData("username", user.Username),
+		user,
+	), nil
+}
+
+func (handler UserHandler) UpdateUser(ctx context.Context, req *userpb.UpdateUserRequest) (*userpb.UserResponse, error) {
+	updateReq := userModels.UpdateUserRequest{
+		ID:       uint(req.GetId()),
+		Username: req.Username,
+		Password: req.Password,
+		Roles:    req.GetRoles(),
+		Salary:   req.Salary,
+	}
+	if err := handler.validate(ctx, updateReq); err != nil {
+		return handler.userError(err)
+	}
+
+	user, err := handler.userServices.Update(ctx, updateReq)
+	if err != nil {
+		return handler.userError(err)
+	}
+
+	return handler.userResponse(ctx,
+		goresponse.NewResponseBuilder(constant.IsResponseUserUpdated).
+			WithContext(ctx).
+			SetData("username", user.Username),
+		user,
+	), nil
+}
+
+func (handler UserHandler) DeleteUser(ctx context.Context, req *userpb.DeleteUserRequest) (*userpb.DeleteUserResponse, error) {
+	user, err := handler.userServices.Delete(ctx, uint(req.GetId()))
+	if err != nil {
+		grpcResponse := handler.grpcEntities.ResponseFormaterError(err)
+
+		return &userpb.DeleteUserResponse{
+			Meta: &userpb.Meta{
+				Message: grpcResponse.Meta.Message,
+				Error:   &grpcResponse.Meta.Error,
+			},
+		}, status.Errorf(grpc.IntToCode(grpcResponse.Code), "%s", grpcResponse.Meta.Message)
+	}
+
+	grpcResponse := handler.grpcEntities.ResponseFormater(
+		goresponse.NewResponseBuilder(constant.IsResponseUserDeleted).
+			WithContext(ctx).
+			SetData("username", user.Username),
+	)
+
+	return &userpb.DeleteUserResponse{
+		Meta: &userpb.Meta{
+			Message: grpcResponse.Meta.Message,
+		},
+	}, nil
+}
+
+func (handler UserHandler) validate(ctx context.Context, req any) error {
+	if err := handler.validator.Validate(req); err != nil {
+		var fields []string
+		var validationErr *validator.ValidationErrors
+		if errors.As(err, &validationErr) {
+			fields = validationErr.Fields()
+		}
+
+		return goresponse.NewResponseBuilder(constant.ErrorValidationFailed).
+			WithContext(ctx).
+			SetData("field", strings.Join(fields, ", ")).
+			SetError(err).
+			ToError()
+	}
+	return nil
+}
+
+func (handler UserHandler) userResponse(ctx context.Context, res *goresponse.ResponseBuilder, user *userModels.User) *userpb.UserResponse {
+	grpcResponse := handler.grpcEntities.ResponseFormater(res)
+
+	return &userpb.UserResponse{
+		Meta: &userpb.Meta{
+			Message: grpcResponse.Meta.Message,
+		},
+		Data: toUserData(user),
+	}
+}
+
+func (handler UserHandler) userError(err error) (*userpb.UserResponse, error) {
+	grpcResponse := handler.grpcEntities.ResponseFormaterError(err)
+
+	return &userpb.UserResponse{
+		Meta: &userpb.Meta{
+			Message: grpcResponse.Meta.Message,
+			Error:   &grpcResponse.Meta.Error,
+		},
+	}, status.Errorf(grpc.IntToCode(grpcResponse.Code), "%s", grpcResponse.Meta.Message)
+}
+
+func (handler UserHandler) listError(err error) (*userpb.ListUsersResponse, error) {
+	grpcResponse := handler.grpcEntities.ResponseFormaterError(err)
+
+	return &userpb.ListUsersResponse{
+		Meta: &userpb.Meta{
+			Message: grpcResponse.Meta.Message,
+			Error:   &grpcResponse.Meta.Error,
+		},
+	}, status.Errorf(grpc.IntToCode(grpcResponse.Code), "%s", grpcResponse.Meta.Message)
+}
+
+func toUserData(user *userModels.User) *userpb.UserData {
+	data := &userpb.UserData{
+		Id:        uint64(user.ID),
+		Username:  user.Username,
+		Roles:     user.Roles,
+		Salary:    user.Salary,
+		CreatedBy: uint64(user.CreatedBy),
+		CreatedAt: user.CreatedAt.Format(time.RFC3339),
+	}
+	if user.UpdatedBy != nil {
+		updatedBy := uint64(*user.UpdatedBy)
+		data.UpdatedBy = &updatedBy
+	}
+	if user.UpdatedAt != nil {
+		updatedAt := user.UpdatedAt.Format(time.RFC3339)
+		data.UpdatedAt = &updatedAt
+	}
+	return data
+}
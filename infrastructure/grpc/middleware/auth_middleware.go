@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/goresponse"
+	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/constant"
+	authServices "go.risoftinc.com/xarch/domain/services/auth"
+	"go.risoftinc.com/xarch/infrastructure/grpc/entities"
+	pb "go.risoftinc.com/xarch/infrastructure/grpc/proto"
+	"go.risoftinc.com/xarch/utils/token"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpchealthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AuthorizationHeader is the metadata key of the bearer token, gRPC metadata keys are lower-case
+const AuthorizationHeader = "authorization"
+
+// publicServices are callable without a token, probes and reflection clients carry no credentials
+var publicServices = []string{
+	grpchealthpb.Health_ServiceDesc.ServiceName,
+	pb.HealthService_ServiceDesc.ServiceName,
+	"grpc.reflection.v1.ServerReflection",
+	"grpc.reflection.v1alpha.ServerReflection",
+}
+
+// publicMethods are callable without a token because they issue tokens
+var publicMethods = []string{
+	pb.AuthService_Login_FullMethodName,
+	pb.AuthService_Refresh_FullMethodName,
+}
+
+type (
+	IAuthMiddleware interface {
+		UnaryAuthInterceptor() grpc.UnaryServerInterceptor
+		StreamAuthInterceptor() grpc.StreamServerInterceptor
+	}
+	AuthMiddleware struct {
+		logger       gologger.Logger
+		entities     entities.IGrpcEntities
+		verifier     token.IVerifier
+		authServices authServices.IAuthServices
+		public       map[string]bool
+	}
+)
+
+func NewAuthMiddleware(logger gologger.Logger, entities entities.IGrpcEntities, verifier token.IVerifier, authServices authServices.IAuthServices, cfg config.Config) IAuthMiddleware {
+	public := make(map[string]bool)
+	for _, service := range publicServices {
+		public["/"+service+"/*"] = true
+	}
+	for _, method := range append(publicMethods, cfg.Auth.PublicMethods...) {
+		public[method] = true
+	}
+
+	return &AuthMiddleware{
+		logger:       logger,
+		entities:     entities,
+		verifier:     verifier,
+		authServices: authServices,
+		public:       public,
+	}
+}
+
+// UnaryAuthInterceptor requires a valid bearer access token in the authorization metadata
+// and puts its claims in the context, read them with token.ClaimsFromContext(ctx)
+func (am AuthMiddleware) UnaryAuthInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if am.isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := am.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor authenticates streaming calls once when the stream is opened
+func (am AuthMiddleware) StreamAuthInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if am.isPublic(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		ctx, err := am.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &wrappedServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// isPublic matches an exact FullMethod or a whole service written as /package.Service/*
+func (am AuthMiddleware) isPublic(fullMethod string) bool {
+	if am.public[fullMethod] {
+		return true
+	}
+	service := fullMethod[:strings.LastIndex(fullMethod, "/")+1]
+	return am.public[service+"*"]
+}
+
+func (am AuthMiddleware) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.New(nil)
+	}
+
+	claims, err := am.verifier.Verify(token.BearerToken(getMetadataValue(md, AuthorizationHeader)))
+	if err == nil {
+		// Tokens denylisted by a logout are rejected until they expire
+		revoked, lookupErr := am.authServices.IsRevoked(ctx, claims)
+		if lookupErr != nil {
+			am.logger.WithContext(ctx).Error("Failed to check the token denylist").ErrorData(lookupErr).Send()
+			res := am.entities.ResponseFormaterError(
+				goresponse.NewResponseBuilder(constant.ErrorServiceUnavailable).
+					WithContext(ctx).
+					SetError(lookupErr).
+					ToError(),
+			)
+			return ctx, status.Error(codes.Code(res.Code), res.Meta.Message)
+		}
+		if revoked {
+			err = token.ErrRevoked
+		}
+	}
+	if err != nil {
+		am.logger.WithContext(ctx).Warn("Rejected unauthenticated gRPC request").
+			Data("method", fullMethod).
+			Data("peer", GetPeerSubjectFromContext(ctx)).
+			Data("reason", err.Error()).
+			Send()
+
+		res := am.entities.ResponseFormaterError(
+			goresponse.NewResponseBuilder(token.ErrorKey(err)).
+				WithContext(ctx).
+				SetError(err).
+				ToError(),
+		)
+		return ctx, status.Error(codes.Code(res.Code), res.Meta.Message)
+	}
+
+	return token.WithClaims(ctx, claims), nil
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/xarch/config"
+	authModels "go.risoftinc.com/xarch/domain/models/auth"
+	"go.risoftinc.com/xarch/utils/token"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type stubVerifier struct {
+	claims *token.Claims
+	raw    string
+}
+
+func (v *stubVerifier) Verify(raw string) (*token.Claims, error) {
+	v.raw = raw
+	if raw == "" {
+		return nil, token.ErrMissing
+	}
+	return v.claims, nil
+}
+
+type stubAuthServices struct {
+	revoked bool
+}
+
+func (s stubAuthServices) Login(ctx context.Context, req authModels.LoginRequest) (*authModels.TokenPair, error) {
+	return nil, nil
+}
+
+func (s stubAuthServices) Refresh(ctx context.Context, req authModels.RefreshRequest) (*authModels.TokenPair, error) {
+	return nil, nil
+}
+
+func (s stubAuthServices) Logout(ctx context.Context, claims *token.Claims, req authModels.LogoutRequest) error {
+	return nil
+}
+
+func (s stubAuthServices) IsRevoked(ctx context.Context, claims *token.Claims) (bool, error) {
+	return s.revoked, nil
+}
+
+func TestAuthPublicMethods(t *testing.T) {
+	cfg := config.Config{Auth: config.AuthConfig{
+		PublicMethods: []string{"/docs.DocsService/*"},
+	}}
+	am := *NewAuthMiddleware(gologger.Logger{}, nil, &stubVerifier{}, stubAuthServices{}, cfg).(*AuthMiddleware)
+
+	tests := []struct {
+		method string
+		want   bool
+	}{
+		{"/grpc.health.v1.Health/Check", true},
+		{"/health.HealthService/GetHealthMetric", true},
+		{"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo", true},
+		{"/auth.AuthService/Login", true},
+		{"/auth.AuthService/Refresh", true},
+		{"/auth.AuthService/Logout", false},
+		{"/docs.DocsService/List", true},
+		{"/users.UserService/List", false},
+	}
+
+	for _, tt := range tests {
+		if got := am.isPublic(tt.method); got != tt.want {
+			t.Errorf("isPublic(%q) = %v, want %v", tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestUnaryAuthPutsClaimsInContext(t *testing.T) {
+	verifier := &stubVerifier{claims: &token.Claims{Username: "admin", Roles: []string{"admin"}}}
+	am := NewAuthMiddleware(gologger.Logger{}, nil, verifier, stubAuthServices{}, config.Config{})
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(AuthorizationHeader, "bearer abc.def.ghi"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/users.UserService/List"}
+
+	var username string
+	_, err := am.UnaryAuthInterceptor()(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		claims, _ := token.ClaimsFromContext(ctx)
+		username = claims.Username
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("interceptor error = %v", err)
+	}
+	if verifier.raw != "abc.def.ghi" {
+		t.Errorf("verified token = %q, want the token without the scheme", verifier.raw)
+	}
+	if username != "admin" {
+		t.Errorf("claims username = %q, want admin", username)
+	}
+}
@@ -4,18 +4,30 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.risoftinc.com/gologger"
 	"go.risoftinc.com/goresponse"
 	"go.risoftinc.com/xarch/constant"
+	"go.risoftinc.com/xarch/infrastructure/tracing"
+	"go.risoftinc.com/xarch/utils/certificate"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 const (
 	RequestIDHeader = "x-request-id"
 	LanguageHeader  = "x-language"
+
+	// RequestIDAttribute links a span to the log lines of its request
+	RequestIDAttribute = "request.id"
 )
 
+// peerIdentityKey stores the verified client certificate identity in the request context
+type peerIdentityKey struct{}
+
 type (
 	IContextMiddleware interface {
 		UnaryContextInterceptor() grpc.UnaryServerInterceptor
@@ -45,10 +57,11 @@ func (cm ContextMiddleware) UnaryContextInterceptor() grpc.UnaryServerIntercepto
 		requestID := getMetadataValue(md, RequestIDHeader)
 		language := getMetadataValue(md, LanguageHeader)
 
-		// If no request ID in metadata, generate a new UUID
+		// If no request ID in metadata, reuse the trace ID or generate a new UUID
 		if requestID == "" {
-			requestID = uuid.New().String()
+			requestID = newRequestID(ctx)
 		}
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String(RequestIDAttribute, requestID))
 
 		// If no language in metadata, set default language
 		if language == "" {
@@ -59,6 +72,7 @@ func (cm ContextMiddleware) UnaryContextInterceptor() grpc.UnaryServerIntercepto
 		ctx = gologger.WithRequestID(ctx, requestID)
 		ctx = goresponse.WithLanguage(ctx, language)
 		ctx = goresponse.WithProtocol(ctx, constant.ProtocolGrpc)
+		ctx = withPeerIdentity(ctx)
 
 		// Add metadata to outgoing context for client reference
 		ctx = metadata.AppendToOutgoingContext(ctx,
@@ -67,10 +81,14 @@ func (cm ContextMiddleware) UnaryContextInterceptor() grpc.UnaryServerIntercepto
 		)
 
 		// Log the incoming request
+		traceID, spanID := tracing.IDs(ctx)
 		cm.logger.WithContext(ctx).Info("gRPC request started").
 			Data("method", info.FullMethod).
 			Data("request_id", requestID).
 			Data("language", language).
+			Data("peer", GetPeerSubjectFromContext(ctx)).
+			Data("trace_id", traceID).
+			Data("span_id", spanID).
 			Send()
 
 		// Call the actual handler
@@ -81,10 +99,14 @@ func (cm ContextMiddleware) UnaryContextInterceptor() grpc.UnaryServerIntercepto
 			cm.logger.WithContext(ctx).Error("gRPC request failed").
 				Data("method", info.FullMethod).
 				Data("error", err.Error()).
+				Data("trace_id", traceID).
+				Data("span_id", spanID).
 				Send()
 		} else {
 			cm.logger.WithContext(ctx).Info("gRPC request completed").
 				Data("method", info.FullMethod).
+				Data("trace_id", traceID).
+				Data("span_id", spanID).
 				Send()
 		}
 
@@ -106,10 +128,11 @@ func (cm ContextMiddleware) StreamContextInterceptor() grpc.StreamServerIntercep
 		requestID := getMetadataValue(md, RequestIDHeader)
 		language := getMetadataValue(md, LanguageHeader)
 
-		// If no request ID in metadata, generate a new UUID
+		// If no request ID in metadata, reuse the trace ID or generate a new UUID
 		if requestID == "" {
-			requestID = uuid.New().String()
+			requestID = newRequestID(ctx)
 		}
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String(RequestIDAttribute, requestID))
 
 		// If no language in metadata, set default language
 		if language == "" {
@@ -120,6 +143,7 @@ func (cm ContextMiddleware) StreamContextInterceptor() grpc.StreamServerIntercep
 		ctx = gologger.WithRequestID(ctx, requestID)
 		ctx = goresponse.WithLanguage(ctx, language)
 		ctx = goresponse.WithProtocol(ctx, constant.ProtocolGrpc)
+		ctx = withPeerIdentity(ctx)
 
 		// Add metadata to outgoing context for client reference
 		ctx = metadata.AppendToOutgoingContext(ctx,
@@ -134,10 +158,14 @@ func (cm ContextMiddleware) StreamContextInterceptor() grpc.StreamServerIntercep
 		}
 
 		// Log the incoming stream request
+		traceID, spanID := tracing.IDs(ctx)
 		cm.logger.WithContext(ctx).Info("gRPC stream request started").
 			Data("method", info.FullMethod).
 			Data("request_id", requestID).
 			Data("language", language).
+			Data("peer", GetPeerSubjectFromContext(ctx)).
+			Data("trace_id", traceID).
+			Data("span_id", spanID).
 			Send()
 
 		// Call the actual handler
@@ -148,10 +176,14 @@ func (cm ContextMiddleware) StreamContextInterceptor() grpc.StreamServerIntercep
 			cm.logger.WithContext(ctx).Error("gRPC stream request failed").
 				Data("method", info.FullMethod).
 				Data("error", err.Error()).
+				Data("trace_id", traceID).
+				Data("span_id", spanID).
 				Send()
 		} else {
 			cm.logger.WithContext(ctx).Info("gRPC stream request completed").
 				Data("method", info.FullMethod).
+				Data("trace_id", traceID).
+				Data("span_id", spanID).
 				Send()
 		}
 
@@ -178,6 +210,34 @@ func getMetadataValue(md metadata.MD, key string) string {
 	return ""
 }
 
+// newRequestID uses the trace ID when the call is traced, so log lines and spans share one ID
+func newRequestID(ctx context.Context) string {
+	if traceID, _ := tracing.IDs(ctx); traceID != "" {
+		return traceID
+	}
+	return uuid.New().String()
+}
+
+// withPeerIdentity stores the verified client certificate identity of a mutual TLS connection in the context
+func withPeerIdentity(ctx context.Context) context.Context {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return ctx
+	}
+
+	identity, ok := certificate.IdentityFromState(tlsInfo.State)
+	if !ok {
+		return ctx
+	}
+
+	return context.WithValue(ctx, peerIdentityKey{}, identity)
+}
+
 // GetRequestIDFromContext extracts request ID from context
 func GetRequestIDFromContext(ctx context.Context) string {
 	if requestID, ok := ctx.Value(gologger.RequestIDKey).(string); ok {
@@ -193,3 +253,17 @@ func GetLanguageFromContext(ctx context.Context) string {
 	}
 	return constant.DefaultLanguage
 }
+
+// GetPeerIdentityFromContext extracts the verified client certificate identity from context
+func GetPeerIdentityFromContext(ctx context.Context) (certificate.Identity, bool) {
+	identity, ok := ctx.Value(peerIdentityKey{}).(certificate.Identity)
+	return identity, ok
+}
+
+// GetPeerSubjectFromContext extracts the verified client certificate subject from context
+func GetPeerSubjectFromContext(ctx context.Context) string {
+	if identity, ok := GetPeerIdentityFromContext(ctx); ok {
+		return identity.Subject
+	}
+	return ""
+}
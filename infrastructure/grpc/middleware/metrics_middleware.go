@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"go.risoftinc.com/xarch/infrastructure/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+type (
+	IMetricsMiddleware interface {
+		UnaryMetricsInterceptor() grpc.UnaryServerInterceptor
+		StreamMetricsInterceptor() grpc.StreamServerInterceptor
+	}
+	MetricsMiddleware struct {
+		metrics *metrics.Metrics
+	}
+)
+
+func NewMetricsMiddleware(metrics *metrics.Metrics) IMetricsMiddleware {
+	return &MetricsMiddleware{
+		metrics: metrics,
+	}
+}
+
+// UnaryMetricsInterceptor records the count and latency of unary calls per FullMethod and status code.
+// Handlers map goresponse codes to gRPC statuses, so the code label follows the same mappings.
+func (mm MetricsMiddleware) UnaryMetricsInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !mm.metrics.Enabled() {
+			return handler(ctx, req)
+		}
+
+		started := time.Now()
+		resp, err := handler(ctx, req)
+		mm.metrics.ObserveGrpc(info.FullMethod, "unary", status.Code(err).String(), time.Since(started))
+
+		return resp, err
+	}
+}
+
+// StreamMetricsInterceptor records the count and duration of streaming calls per FullMethod and status code
+func (mm MetricsMiddleware) StreamMetricsInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !mm.metrics.Enabled() {
+			return handler(srv, ss)
+		}
+
+		started := time.Now()
+		err := handler(srv, ss)
+		mm.metrics.ObserveGrpc(info.FullMethod, "stream", status.Code(err).String(), time.Since(started))
+
+		return err
+	}
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/goresponse"
+	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/constant"
+	"go.risoftinc.com/xarch/infrastructure/grpc/entities"
+	"go.risoftinc.com/xarch/utils/rbac"
+	"go.risoftinc.com/xarch/utils/token"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MethodRules maps a FullMethod, or a whole service written as /package.Service/*, to the rule it requires.
+// Methods without a rule only require authentication.
+type MethodRules map[string]rbac.Rule
+
+type (
+	IRBACMiddleware interface {
+		UnaryRBACInterceptor(rules MethodRules) grpc.UnaryServerInterceptor
+		StreamRBACInterceptor(rules MethodRules) grpc.StreamServerInterceptor
+	}
+	RBACMiddleware struct {
+		logger   gologger.Logger
+		entities entities.IGrpcEntities
+		policy   rbac.IPolicy
+	}
+)
+
+func NewRBACMiddleware(logger gologger.Logger, entities entities.IGrpcEntities, cfg config.Config) IRBACMiddleware {
+	return &RBACMiddleware{
+		logger:   logger,
+		entities: entities,
+		policy:   rbac.NewPolicy(cfg.RBAC.RolePermissions),
+	}
+}
+
+// UnaryRBACInterceptor checks the rule of the called method, it must run after the auth interceptor
+func (rm RBACMiddleware) UnaryRBACInterceptor(rules MethodRules) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := rm.authorize(ctx, rules, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamRBACInterceptor checks the rule of the called method once when the stream is opened
+func (rm RBACMiddleware) StreamRBACInterceptor(rules MethodRules) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := rm.authorize(ss.Context(), rules, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// authorize answers PermissionDenied when the rule is not met, decisions are logged with the request ID
+func (rm RBACMiddleware) authorize(ctx context.Context, rules MethodRules, fullMethod string) error {
+	rule, ok := rules.lookup(fullMethod)
+	if !ok {
+		return nil
+	}
+
+	claims, ok := token.ClaimsFromContext(ctx)
+	if !ok {
+		// A rule on a public method can never be met, fail closed
+		rm.logger.WithContext(ctx).Error("Access policy used without authentication").
+			Data("method", fullMethod).
+			Send()
+
+		res := rm.entities.ResponseFormaterError(
+			goresponse.NewResponseBuilder(constant.ErrorUnauthorized).
+				WithContext(ctx).
+				SetError(token.ErrMissing).
+				ToError(),
+		)
+		return status.Error(codes.Code(res.Code), res.Meta.Message)
+	}
+
+	decision := rm.policy.Authorize(claims.Roles, rule)
+	if !decision.Allowed {
+		rm.logger.WithContext(ctx).Warn("Denied by access policy").
+			Data("method", fullMethod).
+			Data("subject", claims.Subject).
+			Data("roles", claims.Roles).
+			Data("reason", decision.Reason).
+			Send()
+
+		res := rm.entities.ResponseFormaterError(
+			goresponse.NewResponseBuilder(constant.ErrorForbidden).
+				WithContext(ctx).
+				SetError(errors.New(decision.Reason)).
+				ToError(),
+		)
+		return status.Error(codes.Code(res.Code), res.Meta.Message)
+	}
+
+	rm.logger.WithContext(ctx).Debug("Allowed by access policy").
+		Data("method", fullMethod).
+		Data("subject", claims.Subject).
+		Send()
+
+	return nil
+}
+
+// lookup prefers the rule of the exact FullMethod over the rule of its service
+func (r MethodRules) lookup(fullMethod string) (rbac.Rule, bool) {
+	if rule, ok := r[fullMethod]; ok {
+		return rule, true
+	}
+	rule, ok := r[fullMethod[:strings.LastIndex(fullMethod, "/")+1]+"*"]
+	return rule, ok
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/goresponse"
+	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/domain/models/response"
+	"go.risoftinc.com/xarch/utils/rbac"
+	"go.risoftinc.com/xarch/utils/token"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stubEntities answers every error with PermissionDenied, enough to check the status code of a denial
+type stubEntities struct{}
+
+func (stubEntities) ResponseFormaterError(err error) *response.Response {
+	return &response.Response{Code: int(codes.PermissionDenied), Meta: response.Meta{Message: "Access forbidden"}}
+}
+
+func (stubEntities) ResponseFormater(res *goresponse.ResponseBuilder) *response.Response {
+	return &response.Response{}
+}
+
+func TestUnaryRBACInterceptor(t *testing.T) {
+	cfg := config.Config{RBAC: config.RBACConfig{RolePermissions: map[string][]string{
+		"admin":    {"*"},
+		"employee": {"users:read"},
+	}}}
+	rules := MethodRules{
+		"/users.UserService/*":      rbac.RequirePermissions("users:read"),
+		"/users.UserService/Delete": rbac.RequireRoles("admin"),
+	}
+	unary := NewRBACMiddleware(gologger.Logger{}, stubEntities{}, cfg).UnaryRBACInterceptor(rules)
+
+	tests := []struct {
+		name   string
+		method string
+		roles  []string
+		want   codes.Code
+	}{
+		{"service rule allows", "/users.UserService/List", []string{"employee"}, codes.OK},
+		{"method rule wins over service rule", "/users.UserService/Delete", []string{"employee"}, codes.PermissionDenied},
+		{"method rule allows", "/users.UserService/Delete", []string{"admin"}, codes.OK},
+		{"method without rule", "/auth.AuthService/Logout", nil, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := token.WithClaims(context.Background(), &token.Claims{Roles: tt.roles})
+			info := &grpc.UnaryServerInfo{FullMethod: tt.method}
+
+			_, err := unary(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			if status.Code(err) != tt.want {
+				t.Errorf("code = %v, want %v", status.Code(err), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.risoftinc.com/xarch/infrastructure/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type (
+	ITracingMiddleware interface {
+		UnaryTracingInterceptor() grpc.UnaryServerInterceptor
+		StreamTracingInterceptor() grpc.StreamServerInterceptor
+	}
+	TracingMiddleware struct {
+		tracing *tracing.Tracing
+	}
+)
+
+func NewTracingMiddleware(tracing *tracing.Tracing) ITracingMiddleware {
+	return &TracingMiddleware{
+		tracing: tracing,
+	}
+}
+
+// UnaryTracingInterceptor continues the trace of the traceparent metadata, or starts a new one,
+// with a server span named after the FullMethod. It runs before the context interceptor.
+// With tracing disabled the incoming trace context is only extracted for the logs.
+func (tm TracingMiddleware) UnaryTracingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if tm.tracing == nil {
+			return handler(ctx, req)
+		}
+		if !tm.tracing.Enabled() {
+			return handler(tm.extract(ctx), req)
+		}
+
+		ctx, span := tm.start(ctx, info.FullMethod)
+		defer span.End()
+
+		resp, err := handler(ctx, req)
+		endSpan(span, err)
+
+		return resp, err
+	}
+}
+
+// StreamTracingInterceptor traces streaming calls the same way, the span covers the whole stream
+func (tm TracingMiddleware) StreamTracingInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if tm.tracing == nil {
+			return handler(srv, ss)
+		}
+		if !tm.tracing.Enabled() {
+			return handler(srv, &wrappedServerStream{ServerStream: ss, ctx: tm.extract(ss.Context())})
+		}
+
+		ctx, span := tm.start(ss.Context(), info.FullMethod)
+		defer span.End()
+
+		err := handler(srv, &wrappedServerStream{ServerStream: ss, ctx: ctx})
+		endSpan(span, err)
+
+		return err
+	}
+}
+
+func (tm TracingMiddleware) start(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	ctx = tm.extract(ctx)
+
+	// FullMethod is /package.Service/Method
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+
+	return tm.tracing.Tracer().Start(ctx, strings.TrimPrefix(fullMethod, "/"),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "grpc"),
+			attribute.String("rpc.service", service),
+			attribute.String("rpc.method", method),
+		),
+	)
+}
+
+// extract adds the trace context of the incoming metadata to ctx
+func (tm TracingMiddleware) extract(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.New(nil)
+	}
+	return tm.tracing.Propagator().Extract(ctx, metadataCarrier(md))
+}
+
+// endSpan records the status code, handlers map goresponse codes to gRPC statuses
+func endSpan(span trace.Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(code)))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, code.String())
+	}
+}
+
+// metadataCarrier adapts incoming gRPC metadata to the propagator
+type metadataCarrier metadata.MD
+
+func (mc metadataCarrier) Get(key string) string {
+	return getMetadataValue(metadata.MD(mc), key)
+}
+
+func (mc metadataCarrier) Set(key, value string) {
+	metadata.MD(mc).Set(key, value)
+}
+
+func (mc metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(mc))
+	for key := range mc {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/infrastructure/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryTracingPropagatesTraceContext(t *testing.T) {
+	const (
+		traceID  = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentID = "00f067aa0ba902b7"
+	)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer provider.Shutdown(context.Background())
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", "00-"+traceID+"-"+parentID+"-01"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/health.HealthService/GetHealth"}
+
+	var requestID string
+	unary := chainUnary(
+		NewTracingMiddleware(tracing.NewTracingWithProvider(provider)).UnaryTracingInterceptor(),
+		NewContextMiddleware(gologger.Logger{}).UnaryContextInterceptor(),
+	)
+	_, err := unary(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		requestID = GetRequestIDFromContext(ctx)
+		return nil, status.Error(codes.Unavailable, "database is down")
+	})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("error = %v, want Unavailable", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+
+	if span.Name != "health.HealthService/GetHealth" {
+		t.Errorf("span name = %q", span.Name)
+	}
+	if span.SpanContext.TraceID().String() != traceID || span.Parent.SpanID().String() != parentID {
+		t.Errorf("span does not continue the incoming trace: trace %s parent %s", span.SpanContext.TraceID(), span.Parent.SpanID())
+	}
+	// Without x-request-id the trace ID becomes the request ID
+	if requestID != traceID {
+		t.Errorf("request ID = %q, want the trace ID", requestID)
+	}
+	if span.Status.Code != otelcodes.Error {
+		t.Errorf("span status = %v, want Error", span.Status)
+	}
+}
+
+func TestUnaryTracingDisabledKeepsTraceContext(t *testing.T) {
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	disabled, err := tracing.NewTracing(config.TracingConfig{Exporter: "none"})
+	if err != nil {
+		t.Fatalf("NewTracing() error = %v", err)
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/health.HealthService/GetHealth"}
+
+	var requestID string
+	unary := chainUnary(
+		NewTracingMiddleware(disabled).UnaryTracingInterceptor(),
+		NewContextMiddleware(gologger.Logger{}).UnaryContextInterceptor(),
+	)
+	if _, err := unary(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		requestID = GetRequestIDFromContext(ctx)
+		return nil, nil
+	}); err != nil {
+		t.Fatalf("unary() error = %v", err)
+	}
+
+	if requestID != traceID {
+		t.Errorf("request ID = %q, want the incoming trace ID", requestID)
+	}
+}
+
+func TestUnaryTracingKeepsSuppliedRequestID(t *testing.T) {
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	provider := sdktrace.NewTracerProvider()
+	defer provider.Shutdown(context.Background())
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"traceparent", "00-"+traceID+"-00f067aa0ba902b7-01",
+		RequestIDHeader, "req-1",
+	))
+	info := &grpc.UnaryServerInfo{FullMethod: "/health.HealthService/GetHealth"}
+
+	var requestID string
+	unary := chainUnary(
+		NewTracingMiddleware(tracing.NewTracingWithProvider(provider)).UnaryTracingInterceptor(),
+		NewContextMiddleware(gologger.Logger{}).UnaryContextInterceptor(),
+	)
+	if _, err := unary(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		requestID = GetRequestIDFromContext(ctx)
+		return nil, nil
+	}); err != nil {
+		t.Fatalf("unary() error = %v", err)
+	}
+
+	// The trace ID is a separate log field, the request ID of the caller stays the one that is logged
+	if requestID != "req-1" {
+		t.Errorf("request ID = %q, want req-1", requestID)
+	}
+}
+
+// chainUnary applies interceptors in order like grpc.ChainUnaryInterceptor
+func chainUnary(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, current := interceptors[i], next
+			next = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, current)
+			}
+		}
+		return next(ctx, req)
+	}
+}
@@ -174,7 +174,11 @@ type HealthMetricData struct {
 	// Status map for different services
 	Status map[string]string `protobuf:"bytes,1,rep,name=status,proto3" json:"status,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Database information
-	Database      *DatabaseInfo `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`
+	Database *DatabaseInfo `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`
+	// Overall status: up, degraded or down
+	Overall string `protobuf:"bytes,3,opt,name=overall,proto3" json:"overall,omitempty"`
+	// Result of every registered dependency check
+	Checks        map[string]*DependencyCheck `protobuf:"bytes,4,rep,name=checks,proto3" json:"checks,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -223,6 +227,102 @@ func (x *HealthMetricData) GetDatabase() *DatabaseInfo {
 	return nil
 }
 
+func (x *HealthMetricData) GetOverall() string {
+	if x != nil {
+		return x.Overall
+	}
+	return ""
+}
+
+func (x *HealthMetricData) GetChecks() map[string]*DependencyCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+// Dependency check result
+type DependencyCheck struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// up, degraded or down
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Whether a failure makes the application down
+	Critical bool `protobuf:"varint,2,opt,name=critical,proto3" json:"critical,omitempty"`
+	// Duration of the last check, e.g. 1.2ms
+	Latency string `protobuf:"bytes,3,opt,name=latency,proto3" json:"latency,omitempty"`
+	// Error of the last check (if any)
+	LastError *string `protobuf:"bytes,4,opt,name=last_error,json=lastError,proto3,oneof" json:"last_error,omitempty"`
+	// Time of the last check in RFC 3339 format
+	CheckedAt     string `protobuf:"bytes,5,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DependencyCheck) Reset() {
+	*x = DependencyCheck{}
+	mi := &file_infrastructure_grpc_proto_health_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DependencyCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependencyCheck) ProtoMessage() {}
+
+func (x *DependencyCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_grpc_proto_health_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependencyCheck.ProtoReflect.Descriptor instead.
+func (*DependencyCheck) Descriptor() ([]byte, []int) {
+	return file_infrastructure_grpc_proto_health_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DependencyCheck) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DependencyCheck) GetCritical() bool {
+	if x != nil {
+		return x.Critical
+	}
+	return false
+}
+
+func (x *DependencyCheck) GetLatency() string {
+	if x != nil {
+		return x.Latency
+	}
+	return ""
+}
+
+func (x *DependencyCheck) GetLastError() string {
+	if x != nil && x.LastError != nil {
+		return *x.LastError
+	}
+	return ""
+}
+
+func (x *DependencyCheck) GetCheckedAt() string {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return ""
+}
+
 // Database information
 type DatabaseInfo struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -242,7 +342,7 @@ type DatabaseInfo struct {
 
 func (x *DatabaseInfo) Reset() {
 	*x = DatabaseInfo{}
-	mi := &file_infrastructure_grpc_proto_health_proto_msgTypes[4]
+	mi := &file_infrastructure_grpc_proto_health_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -254,7 +354,7 @@ func (x *DatabaseInfo) String() string {
 func (*DatabaseInfo) ProtoMessage() {}
 
 func (x *DatabaseInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_infrastructure_grpc_proto_health_proto_msgTypes[4]
+	mi := &file_infrastructure_grpc_proto_health_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -267,7 +367,7 @@ func (x *DatabaseInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DatabaseInfo.ProtoReflect.Descriptor instead.
 func (*DatabaseInfo) Descriptor() ([]byte, []int) {
-	return file_infrastructure_grpc_proto_health_proto_rawDescGZIP(), []int{4}
+	return file_infrastructure_grpc_proto_health_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *DatabaseInfo) GetMaxOpenConnections() int32 {
@@ -346,13 +446,27 @@ const file_infrastructure_grpc_proto_health_proto_rawDesc = "" +
 	"\x04Meta\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x19\n" +
 	"\x05error\x18\x02 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
-	"\x06_error\"\xbd\x01\n" +
+	"\x06_error\"\xe9\x02\n" +
 	"\x10HealthMetricData\x12<\n" +
 	"\x06status\x18\x01 \x03(\v2$.health.HealthMetricData.StatusEntryR\x06status\x120\n" +
-	"\bdatabase\x18\x02 \x01(\v2\x14.health.DatabaseInfoR\bdatabase\x1a9\n" +
+	"\bdatabase\x18\x02 \x01(\v2\x14.health.DatabaseInfoR\bdatabase\x12\x18\n" +
+	"\aoverall\x18\x03 \x01(\tR\aoverall\x12<\n" +
+	"\x06checks\x18\x04 \x03(\v2$.health.HealthMetricData.ChecksEntryR\x06checks\x1a9\n" +
 	"\vStatusEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd6\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aR\n" +
+	"\vChecksEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12-\n" +
+	"\x05value\x18\x02 \x01(\v2\x17.health.DependencyCheckR\x05value:\x028\x01\"\xb1\x01\n" +
+	"\x0fDependencyCheck\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1a\n" +
+	"\bcritical\x18\x02 \x01(\bR\bcritical\x12\x18\n" +
+	"\alatency\x18\x03 \x01(\tR\alatency\x12\"\n" +
+	"\n" +
+	"last_error\x18\x04 \x01(\tH\x00R\tlastError\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"checked_at\x18\x05 \x01(\tR\tcheckedAtB\r\n" +
+	"\v_last_error\"\xd6\x02\n" +
 	"\fDatabaseInfo\x12.\n" +
 	"\x12MaxOpenConnections\x18\x01 \x01(\x05R\x12MaxOpenConnections\x12(\n" +
 	"\x0fOpenConnections\x18\x02 \x01(\x05R\x0fOpenConnections\x12\x14\n" +
@@ -378,27 +492,31 @@ func file_infrastructure_grpc_proto_health_proto_rawDescGZIP() []byte {
 	return file_infrastructure_grpc_proto_health_proto_rawDescData
 }
 
-var file_infrastructure_grpc_proto_health_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_infrastructure_grpc_proto_health_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_infrastructure_grpc_proto_health_proto_goTypes = []any{
 	(*HealthMetricRequest)(nil),  // 0: health.HealthMetricRequest
 	(*HealthMetricResponse)(nil), // 1: health.HealthMetricResponse
 	(*Meta)(nil),                 // 2: health.Meta
 	(*HealthMetricData)(nil),     // 3: health.HealthMetricData
-	(*DatabaseInfo)(nil),         // 4: health.DatabaseInfo
-	nil,                          // 5: health.HealthMetricData.StatusEntry
+	(*DependencyCheck)(nil),      // 4: health.DependencyCheck
+	(*DatabaseInfo)(nil),         // 5: health.DatabaseInfo
+	nil,                          // 6: health.HealthMetricData.StatusEntry
+	nil,                          // 7: health.HealthMetricData.ChecksEntry
 }
 var file_infrastructure_grpc_proto_health_proto_depIdxs = []int32{
 	2, // 0: health.HealthMetricResponse.meta:type_name -> health.Meta
 	3, // 1: health.HealthMetricResponse.data:type_name -> health.HealthMetricData
-	5, // 2: health.HealthMetricData.status:type_name -> health.HealthMetricData.StatusEntry
-	4, // 3: health.HealthMetricData.database:type_name -> health.DatabaseInfo
-	0, // 4: health.HealthService.GetHealthMetric:input_type -> health.HealthMetricRequest
-	1, // 5: health.HealthService.GetHealthMetric:output_type -> health.HealthMetricResponse
-	5, // [5:6] is the sub-list for method output_type
-	4, // [4:5] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	6, // 2: health.HealthMetricData.status:type_name -> health.HealthMetricData.StatusEntry
+	5, // 3: health.HealthMetricData.database:type_name -> health.DatabaseInfo
+	7, // 4: health.HealthMetricData.checks:type_name -> health.HealthMetricData.ChecksEntry
+	4, // 5: health.HealthMetricData.ChecksEntry.value:type_name -> health.DependencyCheck
+	0, // 6: health.HealthService.GetHealthMetric:input_type -> health.HealthMetricRequest
+	1, // 7: health.HealthService.GetHealthMetric:output_type -> health.HealthMetricResponse
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_infrastructure_grpc_proto_health_proto_init() }
@@ -408,13 +526,14 @@ func file_infrastructure_grpc_proto_health_proto_init() {
 	}
 	file_infrastructure_grpc_proto_health_proto_msgTypes[1].OneofWrappers = []any{}
 	file_infrastructure_grpc_proto_health_proto_msgTypes[2].OneofWrappers = []any{}
+	file_infrastructure_grpc_proto_health_proto_msgTypes[4].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_infrastructure_grpc_proto_health_proto_rawDesc), len(file_infrastructure_grpc_proto_health_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,416 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v6.32.0--rc2
+// source: infrastructure/grpc/proto/query.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Filters, sort and page of a list request, the fields are checked against the schema of the list
+type QuerySpec struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Combined with AND
+	Filters []*QueryFilter `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty"`
+	// Applied in order, the unique key of the list is always the last
+	Sort []*QuerySort `protobuf:"bytes,2,rep,name=sort,proto3" json:"sort,omitempty"`
+	// Defaults to 1
+	Page int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	// Defaults to 10, at most 100
+	PerPage int32 `protobuf:"varint,4,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	// Set, even empty, to page with cursors instead of page numbers, then next_cursor or prev_cursor of the previous response
+	Cursor *string `protobuf:"bytes,5,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	// Leaves out the count of the matching rows, the pagination then has no total
+	SkipCount     bool `protobuf:"varint,6,opt,name=skip_count,json=skipCount,proto3" json:"skip_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuerySpec) Reset() {
+	*x = QuerySpec{}
+	mi := &file_infrastructure_grpc_proto_query_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuerySpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuerySpec) ProtoMessage() {}
+
+func (x *QuerySpec) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_grpc_proto_query_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuerySpec.ProtoReflect.Descriptor instead.
+func (*QuerySpec) Descriptor() ([]byte, []int) {
+	return file_infrastructure_grpc_proto_query_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *QuerySpec) GetFilters() []*QueryFilter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *QuerySpec) GetSort() []*QuerySort {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *QuerySpec) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *QuerySpec) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+func (x *QuerySpec) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *QuerySpec) GetSkipCount() bool {
+	if x != nil {
+		return x.SkipCount
+	}
+	return false
+}
+
+// Filter on one field
+type QueryFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Field string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// eq, ne, gt, gte, lt, lte, like or in, defaults to eq
+	Operator string `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	// One value, or every value of the in operator
+	Values        []string `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryFilter) Reset() {
+	*x = QueryFilter{}
+	mi := &file_infrastructure_grpc_proto_query_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryFilter) ProtoMessage() {}
+
+func (x *QueryFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_grpc_proto_query_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryFilter.ProtoReflect.Descriptor instead.
+func (*QueryFilter) Descriptor() ([]byte, []int) {
+	return file_infrastructure_grpc_proto_query_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *QueryFilter) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *QueryFilter) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *QueryFilter) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// Sort on one field
+type QuerySort struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Desc          bool                   `protobuf:"varint,2,opt,name=desc,proto3" json:"desc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuerySort) Reset() {
+	*x = QuerySort{}
+	mi := &file_infrastructure_grpc_proto_query_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuerySort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuerySort) ProtoMessage() {}
+
+func (x *QuerySort) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_grpc_proto_query_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuerySort.ProtoReflect.Descriptor instead.
+func (*QuerySort) Descriptor() ([]byte, []int) {
+	return file_infrastructure_grpc_proto_query_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *QuerySort) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *QuerySort) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+// Page of a list response
+type Pagination struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only set in page mode
+	Page    int32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage int32 `protobuf:"varint,2,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	// Unset when the request skipped the count
+	Total      *int32 `protobuf:"varint,3,opt,name=total,proto3,oneof" json:"total,omitempty"`
+	TotalPages *int32 `protobuf:"varint,4,opt,name=total_pages,json=totalPages,proto3,oneof" json:"total_pages,omitempty"`
+	// Signed cursors of the next and previous pages, empty when there is none
+	NextCursor    string `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	PrevCursor    string `protobuf:"bytes,6,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	HasNext       bool   `protobuf:"varint,7,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pagination) Reset() {
+	*x = Pagination{}
+	mi := &file_infrastructure_grpc_proto_query_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pagination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pagination) ProtoMessage() {}
+
+func (x *Pagination) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_grpc_proto_query_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
+func (*Pagination) Descriptor() ([]byte, []int) {
+	return file_infrastructure_grpc_proto_query_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Pagination) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *Pagination) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+func (x *Pagination) GetTotal() int32 {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return 0
+}
+
+func (x *Pagination) GetTotalPages() int32 {
+	if x != nil && x.TotalPages != nil {
+		return *x.TotalPages
+	}
+	return 0
+}
+
+func (x *Pagination) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *Pagination) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+func (x *Pagination) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+var File_infrastructure_grpc_proto_query_proto protoreflect.FileDescriptor
+
+const file_infrastructure_grpc_proto_query_proto_rawDesc = "" +
+	"\n" +
+	"%infrastructure/grpc/proto/query.proto\x12\x05query\"\xd5\x01\n" +
+	"\tQuerySpec\x12,\n" +
+	"\afilters\x18\x01 \x03(\v2\x12.query.QueryFilterR\afilters\x12$\n" +
+	"\x04sort\x18\x02 \x03(\v2\x10.query.QuerySortR\x04sort\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x19\n" +
+	"\bper_page\x18\x04 \x01(\x05R\aperPage\x12\x1b\n" +
+	"\x06cursor\x18\x05 \x01(\tH\x00R\x06cursor\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"skip_count\x18\x06 \x01(\bR\tskipCountB\t\n" +
+	"\a_cursor\"W\n" +
+	"\vQueryFilter\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1a\n" +
+	"\boperator\x18\x02 \x01(\tR\boperator\x12\x16\n" +
+	"\x06values\x18\x03 \x03(\tR\x06values\"5\n" +
+	"\tQuerySort\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x12\n" +
+	"\x04desc\x18\x02 \x01(\bR\x04desc\"\xf3\x01\n" +
+	"\n" +
+	"Pagination\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x19\n" +
+	"\bper_page\x18\x02 \x01(\x05R\aperPage\x12\x19\n" +
+	"\x05total\x18\x03 \x01(\x05H\x00R\x05total\x88\x01\x01\x12$\n" +
+	"\vtotal_pages\x18\x04 \x01(\x05H\x01R\n" +
+	"totalPages\x88\x01\x01\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\x06 \x01(\tR\n" +
+	"prevCursor\x12\x19\n" +
+	"\bhas_next\x18\a \x01(\bR\ahasNextB\b\n" +
+	"\x06_totalB\x0e\n" +
+	"\f_total_pagesB\x1eZ\x1cgo.risoftinc.com/xarch/protob\x06proto3"
+
+var (
+	file_infrastructure_grpc_proto_query_proto_rawDescOnce sync.Once
+	file_infrastructure_grpc_proto_query_proto_rawDescData []byte
+)
+
+func file_infrastructure_grpc_proto_query_proto_rawDescGZIP() []byte {
+	file_infrastructure_grpc_proto_query_proto_rawDescOnce.Do(func() {
+		file_infrastructure_grpc_proto_query_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_infrastructure_grpc_proto_query_proto_rawDesc), len(file_infrastructure_grpc_proto_query_proto_rawDesc)))
+	})
+	return file_infrastructure_grpc_proto_query_proto_rawDescData
+}
+
+var file_infrastructure_grpc_proto_query_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_infrastructure_grpc_proto_query_proto_goTypes = []any{
+	(*QuerySpec)(nil),   // 0: query.QuerySpec
+	(*QueryFilter)(nil), // 1: query.QueryFilter
+	(*QuerySort)(nil),   // 2: query.QuerySort
+	(*Pagination)(nil),  // 3: query.Pagination
+}
+var file_infrastructure_grpc_proto_query_proto_depIdxs = []int32{
+	1, // 0: query.QuerySpec.filters:type_name -> query.QueryFilter
+	2, // 1: query.QuerySpec.sort:type_name -> query.QuerySort
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_infrastructure_grpc_proto_query_proto_init() }
+func file_infrastructure_grpc_proto_query_proto_init() {
+	if File_infrastructure_grpc_proto_query_proto != nil {
+		return
+	}
+	file_infrastructure_grpc_proto_query_proto_msgTypes[0].OneofWrappers = []any{}
+	file_infrastructure_grpc_proto_query_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_infrastructure_grpc_proto_query_proto_rawDesc), len(file_infrastructure_grpc_proto_query_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_infrastructure_grpc_proto_query_proto_goTypes,
+		DependencyIndexes: file_infrastructure_grpc_proto_query_proto_depIdxs,
+		MessageInfos:      file_infrastructure_grpc_proto_query_proto_msgTypes,
+	}.Build()
+	File_infrastructure_grpc_proto_query_proto = out.File
+	file_infrastructure_grpc_proto_query_proto_goTypes = nil
+	file_infrastructure_grpc_proto_query_proto_depIdxs = nil
+}
@@ -0,0 +1,711 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v6.32.0--rc2
+// source: infrastructure/grpc/proto/user.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Request message for list, filters and sort on id, username, roles, salary, created_by and created_at
+type ListUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         *QuerySpec             `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_infrastructure_grpc_proto_user_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_grpc_proto_user_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_infrastructure_grpc_proto_user_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListUsersRequest) GetQuery() *QuerySpec {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+// Request message for get
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_infrastructure_grpc_proto_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_grpc_proto_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_infrastructure_grpc_proto_user_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetUserRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Request message for create
+type CreateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Roles         []string               `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
+	Salary        float64                `protobuf:"fixed64,4,opt,name=salary,proto3" json:"salary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	mi := &file_infrastructure_grpc_proto_user_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_grpc_proto_user_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_infrastructure_grpc_proto_user_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateUserRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *CreateUserRequest) GetSalary() float64 {
+	if x != nil {
+		return x.Salary
+	}
+	return 0
+}
+
+// Request message for update, unset fields are left unchanged
+type UpdateUserRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username *string                `protobuf:"bytes,2,opt,name=username,proto3,oneof" json:"username,omitempty"`
+	Password *string                `protobuf:"bytes,3,opt,name=password,proto3,oneof" json:"password,omitempty"`
+	// Replaces the roles when not empty
+	Roles         []string `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`
+	Salary        *float64 `protobuf:"fixed64,5,opt,name=salary,proto3,oneof" json:"salary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_infrastructure_grpc_proto_user_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserRequest) ProtoMessage() {}
+
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_grpc_proto_user_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_infrastructure_grpc_proto_user_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateUserRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateUserRequest) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetPassword() string {
+	if x != nil && x.Password != nil {
+		return *x.Password
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetSalary() float64 {
+	if x != nil && x.Salary != nil {
+		return *x.Salary
+	}
+	return 0
+}
+
+// Request message for delete
+type DeleteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
+	mi := &file_infrastructure_grpc_proto_user_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserRequest) ProtoMessage() {}
+
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_grpc_proto_user_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return file_infrastructure_grpc_proto_user_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteUserRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Response message for list
+type ListUsersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Meta information, shared with the health service
+	Meta          *Meta       `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	Data          []*UserData `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	Pagination    *Pagination `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_infrastructure_grpc_proto_user_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_grpc_proto_user_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_infrastructure_grpc_proto_user_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListUsersResponse) GetMeta() *Meta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetData() []*UserData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// Response message for get, create and update
+type UserResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Meta information, shared with the health service
+	Meta          *Meta     `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	Data          *UserData `protobuf:"bytes,2,opt,name=data,proto3,oneof" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserResponse) Reset() {
+	*x = UserResponse{}
+	mi := &file_infrastructure_grpc_proto_user_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserResponse) ProtoMessage() {}
+
+func (x *UserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_grpc_proto_user_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserResponse.ProtoReflect.Descriptor instead.
+func (*UserResponse) Descriptor() ([]byte, []int) {
+	return file_infrastructure_grpc_proto_user_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UserResponse) GetMeta() *Meta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *UserResponse) GetData() *UserData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Response message for delete
+type DeleteUserResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Meta information, shared with the health service
+	Meta          *Meta `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserResponse) Reset() {
+	*x = DeleteUserResponse{}
+	mi := &file_infrastructure_grpc_proto_user_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserResponse) ProtoMessage() {}
+
+func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_grpc_proto_user_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
+	return file_infrastructure_grpc_proto_user_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteUserResponse) GetMeta() *Meta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+// User without its password
+type UserData struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username  string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Roles     []string               `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
+	Salary    float64                `protobuf:"fixed64,4,opt,name=salary,proto3" json:"salary,omitempty"`
+	CreatedBy uint64                 `protobuf:"varint,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	// RFC 3339
+	CreatedAt     string  `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedBy     *uint64 `protobuf:"varint,7,opt,name=updated_by,json=updatedBy,proto3,oneof" json:"updated_by,omitempty"`
+	UpdatedAt     *string `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3,oneof" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserData) Reset() {
+	*x = UserData{}
+	mi := &file_infrastructure_grpc_proto_user_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserData) ProtoMessage() {}
+
+func (x *UserData) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_grpc_proto_user_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserData.ProtoReflect.Descriptor instead.
+func (*UserData) Descriptor() ([]byte, []int) {
+	return file_infrastructure_grpc_proto_user_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UserData) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UserData) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserData) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *UserData) GetSalary() float64 {
+	if x != nil {
+		return x.Salary
+	}
+	return 0
+}
+
+func (x *UserData) GetCreatedBy() uint64 {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return 0
+}
+
+func (x *UserData) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *UserData) GetUpdatedBy() uint64 {
+	if x != nil && x.UpdatedBy != nil {
+		return *x.UpdatedBy
+	}
+	return 0
+}
+
+func (x *UserData) GetUpdatedAt() string {
+	if x != nil && x.UpdatedAt != nil {
+		return *x.UpdatedAt
+	}
+	return ""
+}
+
+var File_infrastructure_grpc_proto_user_proto protoreflect.FileDescriptor
+
+const file_infrastructure_grpc_proto_user_proto_rawDesc = "" +
+	"\n" +
+	"$infrastructure/grpc/proto/user.proto\x12\x04user\x1a&infrastructure/grpc/proto/health.proto\x1a%infrastructure/grpc/proto/query.proto\":\n" +
+	"\x10ListUsersRequest\x12&\n" +
+	"\x05query\x18\x01 \x01(\v2\x10.query.QuerySpecR\x05query\" \n" +
+	"\x0eGetUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"y\n" +
+	"\x11CreateUserRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x14\n" +
+	"\x05roles\x18\x03 \x03(\tR\x05roles\x12\x16\n" +
+	"\x06salary\x18\x04 \x01(\x01R\x06salary\"\xbd\x01\n" +
+	"\x11UpdateUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1f\n" +
+	"\busername\x18\x02 \x01(\tH\x00R\busername\x88\x01\x01\x12\x1f\n" +
+	"\bpassword\x18\x03 \x01(\tH\x01R\bpassword\x88\x01\x01\x12\x14\n" +
+	"\x05roles\x18\x04 \x03(\tR\x05roles\x12\x1b\n" +
+	"\x06salary\x18\x05 \x01(\x01H\x02R\x06salary\x88\x01\x01B\v\n" +
+	"\t_usernameB\v\n" +
+	"\t_passwordB\t\n" +
+	"\a_salary\"#\n" +
+	"\x11DeleteUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"\x8c\x01\n" +
+	"\x11ListUsersResponse\x12 \n" +
+	"\x04meta\x18\x01 \x01(\v2\f.health.MetaR\x04meta\x12\"\n" +
+	"\x04data\x18\x02 \x03(\v2\x0e.user.UserDataR\x04data\x121\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x11.query.PaginationR\n" +
+	"pagination\"b\n" +
+	"\fUserResponse\x12 \n" +
+	"\x04meta\x18\x01 \x01(\v2\f.health.MetaR\x04meta\x12'\n" +
+	"\x04data\x18\x02 \x01(\v2\x0e.user.UserDataH\x00R\x04data\x88\x01\x01B\a\n" +
+	"\x05_data\"6\n" +
+	"\x12DeleteUserResponse\x12 \n" +
+	"\x04meta\x18\x01 \x01(\v2\f.health.MetaR\x04meta\"\x88\x02\n" +
+	"\bUserData\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05roles\x18\x03 \x03(\tR\x05roles\x12\x16\n" +
+	"\x06salary\x18\x04 \x01(\x01R\x06salary\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x05 \x01(\x04R\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\"\n" +
+	"\n" +
+	"updated_by\x18\a \x01(\x04H\x00R\tupdatedBy\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\tH\x01R\tupdatedAt\x88\x01\x01B\r\n" +
+	"\v_updated_byB\r\n" +
+	"\v_updated_at2\xb7\x02\n" +
+	"\vUserService\x12<\n" +
+	"\tListUsers\x12\x16.user.ListUsersRequest\x1a\x17.user.ListUsersResponse\x123\n" +
+	"\aGetUser\x12\x14.user.GetUserRequest\x1a\x12.user.UserResponse\x129\n" +
+	"\n" +
+	"CreateUser\x12\x17.user.CreateUserRequest\x1a\x12.user.UserResponse\x129\n" +
+	"\n" +
+	"UpdateUser\x12\x17.user.UpdateUserRequest\x1a\x12.user.UserResponse\x12?\n" +
+	"\n" +
+	"DeleteUser\x12\x17.user.DeleteUserRequest\x1a\x18.user.DeleteUserResponseB\x1eZ\x1cgo.risoftinc.com/xarch/protob\x06proto3"
+
+var (
+	file_infrastructure_grpc_proto_user_proto_rawDescOnce sync.Once
+	file_infrastructure_grpc_proto_user_proto_rawDescData []byte
+)
+
+func file_infrastructure_grpc_proto_user_proto_rawDescGZIP() []byte {
+	file_infrastructure_grpc_proto_user_proto_rawDescOnce.Do(func() {
+		file_infrastructure_grpc_proto_user_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_infrastructure_grpc_proto_user_proto_rawDesc), len(file_infrastructure_grpc_proto_user_proto_rawDesc)))
+	})
+	return file_infrastructure_grpc_proto_user_proto_rawDescData
+}
+
+var file_infrastructure_grpc_proto_user_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_infrastructure_grpc_proto_user_proto_goTypes = []any{
+	(*ListUsersRequest)(nil),   // 0: user.ListUsersRequest
+	(*GetUserRequest)(nil),     // 1: user.GetUserRequest
+	(*CreateUserRequest)(nil),  // 2: user.CreateUserRequest
+	(*UpdateUserRequest)(nil),  // 3: user.UpdateUserRequest
+	(*DeleteUserRequest)(nil),  // 4: user.DeleteUserRequest
+	(*ListUsersResponse)(nil),  // 5: user.ListUsersResponse
+	(*UserResponse)(nil),       // 6: user.UserResponse
+	(*DeleteUserResponse)(nil), // 7: user.DeleteUserResponse
+	(*UserData)(nil),           // 8: user.UserData
+	(*QuerySpec)(nil),          // 9: query.QuerySpec
+	(*Meta)(nil),               // 10: health.Meta
+	(*Pagination)(nil),         // 11: query.Pagination
+}
+var file_infrastructure_grpc_proto_user_proto_depIdxs = []int32{
+	9,  // 0: user.ListUsersRequest.query:type_name -> query.QuerySpec
+	10, // 1: user.ListUsersResponse.meta:type_name -> health.Meta
+	8,  // 2: user.ListUsersResponse.data:type_name -> user.UserData
+	11, // 3: user.ListUsersResponse.pagination:type_name -> query.Pagination
+	10, // 4: user.UserResponse.meta:type_name -> health.Meta
+	8,  // 5: user.UserResponse.data:type_name -> user.UserData
+	10, // 6: user.DeleteUserResponse.meta:type_name -> health.Meta
+	0,  // 7: user.UserService.ListUsers:input_type -> user.ListUsersRequest
+	1,  // 8: user.UserService.GetUser:input_type -> user.GetUserRequest
+	2,  // 9: user.UserService.CreateUser:input_type -> user.CreateUserRequest
+	3,  // 10: user.UserService.UpdateUser:input_type -> user.UpdateUserRequest
+	4,  // 11: user.UserService.DeleteUser:input_type -> user.DeleteUserRequest
+	5,  // 12: user.UserService.ListUsers:output_type -> user.ListUsersResponse
+	6,  // 13: user.UserService.GetUser:output_type -> user.UserResponse
+	6,  // 14: user.UserService.CreateUser:output_type -> user.UserResponse
+	6,  // 15: user.UserService.UpdateUser:output_type -> user.UserResponse
+	7,  // 16: user.UserService.DeleteUser:output_type -> user.DeleteUserResponse
+	12, // [12:17] is the sub-list for method output_type
+	7,  // [7:12] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_infrastructure_grpc_proto_user_proto_init() }
+func file_infrastructure_grpc_proto_user_proto_init() {
+	if File_infrastructure_grpc_proto_user_proto != nil {
+		return
+	}
+	file_infrastructure_grpc_proto_health_proto_init()
+	file_infrastructure_grpc_proto_query_proto_init()
+	file_infrastructure_grpc_proto_user_proto_msgTypes[3].OneofWrappers = []any{}
+	file_infrastructure_grpc_proto_user_proto_msgTypes[6].OneofWrappers = []any{}
+	file_infrastructure_grpc_proto_user_proto_msgTypes[8].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_infrastructure_grpc_proto_user_proto_rawDesc), len(file_infrastructure_grpc_proto_user_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_infrastructure_grpc_proto_user_proto_goTypes,
+		DependencyIndexes: file_infrastructure_grpc_proto_user_proto_depIdxs,
+		MessageInfos:      file_infrastructure_grpc_proto_user_proto_msgTypes,
+	}.Build()
+	File_infrastructure_grpc_proto_user_proto = out.File
+	file_infrastructure_grpc_proto_user_proto_goTypes = nil
+	file_infrastructure_grpc_proto_user_proto_depIdxs = nil
+}
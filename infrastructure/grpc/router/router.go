@@ -2,21 +2,55 @@ package router
 
 import (
 	dep "go.risoftinc.com/xarch/infrastructure/grpc"
-	healthpb "go.risoftinc.com/xarch/infrastructure/grpc/proto"
+	"go.risoftinc.com/xarch/infrastructure/grpc/middleware"
+	pb "go.risoftinc.com/xarch/infrastructure/grpc/proto"
+	"go.risoftinc.com/xarch/utils/rbac"
 	"google.golang.org/grpc"
+	grpchealthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
-// RegisterGRPCServices registers all gRPC services
-func RegisterGRPCServices(dep *dep.Dependencies) *grpc.Server {
+// methodRules declares the roles or permissions an RPC requires on top of a valid token
+var methodRules = middleware.MethodRules{
+	pb.UserService_ListUsers_FullMethodName:  rbac.RequirePermissions("users:read"),
+	pb.UserService_GetUser_FullMethodName:    rbac.RequirePermissions("users:read"),
+	pb.UserService_CreateUser_FullMethodName: rbac.RequirePermissions("users:create"),
+	pb.UserService_UpdateUser_FullMethodName: rbac.RequirePermissions("users:update"),
+	pb.UserService_DeleteUser_FullMethodName: rbac.RequirePermissions("users:delete"),
+}
+
+// RegisterGRPCServices registers all gRPC services.
+// Extra server options such as transport credentials are applied after the interceptors.
+func RegisterGRPCServices(dep *dep.Dependencies, opts ...grpc.ServerOption) *grpc.Server {
 	// Initialize gRPC server with interceptors
-	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(dep.Middlewares.UnaryContextInterceptor()),
-		grpc.StreamInterceptor(dep.Middlewares.StreamContextInterceptor()),
-	)
+	grpcServer := grpc.NewServer(append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			dep.TracingMiddleware.UnaryTracingInterceptor(),
+			dep.Middlewares.UnaryContextInterceptor(),
+			dep.MetricsMiddleware.UnaryMetricsInterceptor(),
+			dep.AuthMiddleware.UnaryAuthInterceptor(),
+			dep.RBACMiddleware.UnaryRBACInterceptor(methodRules),
+		),
+		grpc.ChainStreamInterceptor(
+			dep.TracingMiddleware.StreamTracingInterceptor(),
+			dep.Middlewares.StreamContextInterceptor(),
+			dep.MetricsMiddleware.StreamMetricsInterceptor(),
+			dep.AuthMiddleware.StreamAuthInterceptor(),
+			dep.RBACMiddleware.StreamRBACInterceptor(methodRules),
+		),
+	}, opts...)...)
 
 	// Register health service
-	healthpb.RegisterHealthServiceServer(grpcServer, dep.HealthHandlers)
+	pb.RegisterHealthServiceServer(grpcServer, dep.HealthHandlers)
+
+	// Register auth service, Login and Refresh are public
+	pb.RegisterAuthServiceServer(grpcServer, dep.AuthHandlers)
+
+	// Register user service, permissions are declared in methodRules
+	pb.RegisterUserServiceServer(grpcServer, dep.UserHandlers)
+
+	// Register the standard grpc.health.v1 service for probes and load balancers
+	grpchealthpb.RegisterHealthServer(grpcServer, dep.HealthStatusHandlers)
 
 	// Enable reflection for debugging and testing
 	reflection.Register(grpcServer)
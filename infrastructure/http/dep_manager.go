@@ -4,21 +4,45 @@
 package http
 
 import (
+	"github.com/redis/go-redis/v9"
 	"go.risoftinc.com/elsa"
 	"go.risoftinc.com/gologger"
 	"go.risoftinc.com/goresponse"
 	"go.risoftinc.com/xarch/config"
+	authRepo "go.risoftinc.com/xarch/domain/repositories/auth"
 	healthRepo "go.risoftinc.com/xarch/domain/repositories/health"
+	"go.risoftinc.com/xarch/domain/repositories/instance"
+	userRepo "go.risoftinc.com/xarch/domain/repositories/user"
+	authSvc "go.risoftinc.com/xarch/domain/services/auth"
 	healthSvc "go.risoftinc.com/xarch/domain/services/health"
+	userSvc "go.risoftinc.com/xarch/domain/services/user"
 	entities "go.risoftinc.com/xarch/infrastructure/http/entities"
+	adminHandler "go.risoftinc.com/xarch/infrastructure/http/handler/admin"
+	authHandler "go.risoftinc.com/xarch/infrastructure/http/handler/auth"
 	healthHandler "go.risoftinc.com/xarch/infrastructure/http/handler/health"
+	metricsHandler "go.risoftinc.com/xarch/infrastructure/http/handler/metrics"
+	userHandler "go.risoftinc.com/xarch/infrastructure/http/handler/user"
 	mid "go.risoftinc.com/xarch/infrastructure/http/middleware"
+	"go.risoftinc.com/xarch/infrastructure/metrics"
+	"go.risoftinc.com/xarch/infrastructure/tracing"
+	"go.risoftinc.com/xarch/utils/healthcheck"
+	"go.risoftinc.com/xarch/utils/token"
 	"gorm.io/gorm"
 )
 
 type Dependencies struct {
-	Middlewares    mid.IContextMiddleware
-	HealthHandlers healthHandler.IHealthHandler
+	Middlewares         mid.IContextMiddleware
+	AdminMiddleware     mid.IAdminMiddleware
+	MetricsMiddleware   mid.IMetricsMiddleware
+	TracingMiddleware   mid.ITracingMiddleware
+	AuthMiddleware      mid.IAuthMiddleware
+	RBACMiddleware      mid.IRBACMiddleware
+	AccessLogMiddleware mid.IAccessLogMiddleware
+	HealthHandlers      healthHandler.IHealthHandler
+	AdminHandlers       adminHandler.IAdminHandler
+	AuthHandlers        authHandler.IAuthHandler
+	UserHandlers        userHandler.IUserHandler
+	MetricsHandlers     metricsHandler.IMetricsHandler
 }
 
 func InitializeServices(
@@ -26,6 +50,13 @@ func InitializeServices(
 	cfg config.Config,
 	logger gologger.Logger,
 	async *goresponse.AsyncConfigManager,
+	healthRegistry healthcheck.IRegistry,
+	probes healthcheck.IProbes,
+	metrics *metrics.Metrics,
+	tracing *tracing.Tracing,
+	verifier token.IVerifier,
+	signer token.ISigner,
+	redisClient *redis.Client,
 ) *Dependencies {
 	elsa.Generate(
 		RepositorySet,
@@ -40,14 +71,24 @@ func InitializeServices(
 
 var RepositorySet = elsa.Set(
 	healthRepo.NewHealthRepositories,
+	authRepo.NewAuthRepositories,
+	authRepo.NewDenylistRepositories,
+	instance.NewInstanceRepository,
+	userRepo.NewUserRepositories,
 )
 
 var ServicesSet = elsa.Set(
 	healthSvc.NewHealthService,
+	authSvc.NewAuthService,
+	userSvc.NewUserService,
 )
 
 var HandlerSet = elsa.Set(
 	healthHandler.NewHealthHandlers,
+	adminHandler.NewAdminHandlers,
+	authHandler.NewAuthHandlers,
+	userHandler.NewUserHandlers,
+	metricsHandler.NewMetricsHandlers,
 )
 
 var EntitiesSet = elsa.Set(
@@ -56,4 +97,10 @@ var EntitiesSet = elsa.Set(
 
 var MidlewareSet = elsa.Set(
 	mid.NewContextMiddleware,
+	mid.NewAdminMiddleware,
+	mid.NewMetricsMiddleware,
+	mid.NewTracingMiddleware,
+	mid.NewAuthMiddleware,
+	mid.NewRBACMiddleware,
+	mid.NewAccessLogMiddleware,
 )
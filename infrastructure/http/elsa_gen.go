@@ -9,6 +9,10 @@ package http
 import (
 	"go.risoftinc.com/elsa"
 
+	adminHandler "go.risoftinc.com/xarch/infrastructure/http/handler/admin"
+	authHandler "go.risoftinc.com/xarch/infrastructure/http/handler/auth"
+	authRepo "go.risoftinc.com/xarch/domain/repositories/auth"
+	authSvc "go.risoftinc.com/xarch/domain/services/auth"
 	config "go.risoftinc.com/xarch/config"
 	entities "go.risoftinc.com/xarch/infrastructure/http/entities"
 	gologger "go.risoftinc.com/gologger"
@@ -17,27 +21,73 @@ import (
 	healthHandler "go.risoftinc.com/xarch/infrastructure/http/handler/health"
 	healthRepo "go.risoftinc.com/xarch/domain/repositories/health"
 	healthSvc "go.risoftinc.com/xarch/domain/services/health"
+	healthcheck "go.risoftinc.com/xarch/utils/healthcheck"
+	instance "go.risoftinc.com/xarch/domain/repositories/instance"
+	metrics "go.risoftinc.com/xarch/infrastructure/metrics"
+	metricsHandler "go.risoftinc.com/xarch/infrastructure/http/handler/metrics"
 	mid "go.risoftinc.com/xarch/infrastructure/http/middleware"
+	redis "github.com/redis/go-redis/v9"
+	token "go.risoftinc.com/xarch/utils/token"
+	tracing "go.risoftinc.com/xarch/infrastructure/tracing"
+	userHandler "go.risoftinc.com/xarch/infrastructure/http/handler/user"
+	userRepo "go.risoftinc.com/xarch/domain/repositories/user"
+	userSvc "go.risoftinc.com/xarch/domain/services/user"
 )
 
 // This file generated from dep_manager.go at 2025-09-12T18:18:39+07:00
 
 type Dependencies struct {
-	Middlewares    mid.IContextMiddleware
-	HealthHandlers healthHandler.IHealthHandler
+	Middlewares         mid.IContextMiddleware
+	AdminMiddleware     mid.IAdminMiddleware
+	MetricsMiddleware   mid.IMetricsMiddleware
+	TracingMiddleware   mid.ITracingMiddleware
+	AuthMiddleware      mid.IAuthMiddleware
+	RBACMiddleware      mid.IRBACMiddleware
+	AccessLogMiddleware mid.IAccessLogMiddleware
+	HealthHandlers      healthHandler.IHealthHandler
+	AdminHandlers       adminHandler.IAdminHandler
+	AuthHandlers        authHandler.IAuthHandler
+	UserHandlers        userHandler.IUserHandler
+	MetricsHandlers     metricsHandler.IMetricsHandler
 }
 
-func InitializeServices(db *gorm.DB, cfg config.Config, logger gologger.Logger, async *goresponse.AsyncConfigManager) *Dependencies {
+func InitializeServices(db *gorm.DB, cfg config.Config, logger gologger.Logger, async *goresponse.AsyncConfigManager, healthRegistry healthcheck.IRegistry, probes healthcheck.IProbes, metrics *metrics.Metrics, tracing *tracing.Tracing, verifier token.IVerifier, signer token.ISigner, redisClient *redis.Client) *Dependencies {
 	iHealthRepositories := healthRepo.NewHealthRepositories(db)
-	iHealthServices := healthSvc.NewHealthService(logger, iHealthRepositories)
+	iAuthRepositories := authRepo.NewAuthRepositories(db)
+	iDenylistRepositories := authRepo.NewDenylistRepositories(db, redisClient, cfg)
+	iInstanceRepository := instance.NewInstanceRepository(db)
+	iUserRepositories := userRepo.NewUserRepositories(db, cfg)
+	iHealthServices := healthSvc.NewHealthService(logger, iHealthRepositories, healthRegistry)
+	iAuthServices := authSvc.NewAuthService(logger, cfg, signer, iAuthRepositories, iDenylistRepositories)
+	iUserServices := userSvc.NewUserService(logger, cfg, iInstanceRepository, iUserRepositories)
 	iEntities := entities.NewEntities(async)
 	iContextMiddleware := mid.NewContextMiddleware(logger)
-	iHealthHandler := healthHandler.NewHealthHandlers(logger, iEntities, iHealthServices)
+	iAdminMiddleware := mid.NewAdminMiddleware(logger, iEntities, cfg)
+	iMetricsMiddleware := mid.NewMetricsMiddleware(metrics)
+	iTracingMiddleware := mid.NewTracingMiddleware(tracing)
+	iAuthMiddleware := mid.NewAuthMiddleware(logger, iEntities, verifier, iAuthServices)
+	iRBACMiddleware := mid.NewRBACMiddleware(logger, iEntities, cfg)
+	iAccessLogMiddleware := mid.NewAccessLogMiddleware(logger, cfg)
+	iHealthHandler := healthHandler.NewHealthHandlers(logger, iEntities, iHealthServices, probes)
+	iAdminHandler := adminHandler.NewAdminHandlers(logger, iEntities, cfg)
+	iAuthHandler := authHandler.NewAuthHandlers(logger, iEntities, iAuthServices)
+	iUserHandler := userHandler.NewUserHandlers(logger, iEntities, iUserServices)
+	iMetricsHandler := metricsHandler.NewMetricsHandlers(metrics)
 
-	elsa.Generate(iHealthRepositories, iHealthServices, iEntities, iContextMiddleware, iHealthHandler)
+	elsa.Generate(iHealthRepositories, iAuthRepositories, iDenylistRepositories, iInstanceRepository, iUserRepositories, iHealthServices, iAuthServices, iUserServices, iEntities, iContextMiddleware, iAdminMiddleware, iMetricsMiddleware, iTracingMiddleware, iAuthMiddleware, iRBACMiddleware, iAccessLogMiddleware, iHealthHandler, iAdminHandler, iAuthHandler, iUserHandler, iMetricsHandler)
 	return &Dependencies{
-		Middlewares:    iContextMiddleware,
-		HealthHandlers: iHealthHandler,
+		Middlewares:         iContextMiddleware,
+		AdminMiddleware:     iAdminMiddleware,
+		MetricsMiddleware:   iMetricsMiddleware,
+		TracingMiddleware:   iTracingMiddleware,
+		AuthMiddleware:      iAuthMiddleware,
+		RBACMiddleware:      iRBACMiddleware,
+		AccessLogMiddleware: iAccessLogMiddleware,
+		HealthHandlers:      iHealthHandler,
+		AdminHandlers:       iAdminHandler,
+		AuthHandlers:        iAuthHandler,
+		UserHandlers:        iUserHandler,
+		MetricsHandlers:     iMetricsHandler,
 	}
 }
 
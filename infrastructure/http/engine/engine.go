@@ -2,19 +2,26 @@ package engine
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
-	"os"
-	"os/signal"
-	"sync"
-	"syscall"
-	"time"
 
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
 	"go.risoftinc.com/gologger"
 	"go.risoftinc.com/goresponse"
 	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/constant"
 	dep "go.risoftinc.com/xarch/infrastructure/http"
 	"go.risoftinc.com/xarch/infrastructure/http/router"
+	"go.risoftinc.com/xarch/infrastructure/lifecycle"
+	"go.risoftinc.com/xarch/infrastructure/metrics"
+	"go.risoftinc.com/xarch/infrastructure/tracing"
+	"go.risoftinc.com/xarch/utils/certificate"
+	"go.risoftinc.com/xarch/utils/healthcheck"
+	"go.risoftinc.com/xarch/utils/token"
 	"gorm.io/gorm"
 )
 
@@ -23,42 +30,77 @@ type App struct {
 	Logger          gologger.Logger
 	DB              *gorm.DB
 	ResponseManager *goresponse.AsyncConfigManager
+	HealthRegistry  healthcheck.IRegistry
+	Probes          healthcheck.IProbes
+	Metrics         *metrics.Metrics
+	Tracing         *tracing.Tracing
+	Verifier        token.IVerifier
+	Signer          token.ISigner
+	Redis           *redis.Client // connected with REDIS_ENABLED, nil otherwise
 }
 
-func Start(app App, wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		// Initialize HTTP server
-		e := router.Routers(dep.InitializeServices(app.DB, app.Config, app.Logger, app.ResponseManager))
+// Component returns the HTTP server as a lifecycle component.
+// The port is bound on start so address errors are reported before the application is up.
+func Component(app App) lifecycle.Component {
+	var e *echo.Echo
+	address := fmt.Sprintf("%s:%d", app.Config.Http.Server, app.Config.Http.Port)
 
-		// Start HTTP server in background
-		go func() {
+	return lifecycle.Component{
+		Name:      constant.ComponentHttpServer,
+		DependsOn: []string{constant.ComponentDatabase, constant.ComponentResponseManager},
+		Start: func(ctx context.Context) error {
+			// Initialize HTTP server
+			e = router.Routers(dep.InitializeServices(app.DB, app.Config, app.Logger, app.ResponseManager, app.HealthRegistry, app.Probes, app.Metrics, app.Tracing, app.Verifier, app.Signer, app.Redis))
 			e.HideBanner = true
-			app.Logger.Info(fmt.Sprintf("HTTP server starting on %s:%d", app.Config.Http.Server, app.Config.Http.Port)).Send()
-			if err := e.Start(fmt.Sprintf("%s:%d", app.Config.Http.Server, app.Config.Http.Port)); err != nil && err != http.ErrServerClosed {
-				app.Logger.Fatal("shutting down the server").Send()
-			}
-		}()
 
-		// Wait for interrupt signal to gracefully shutdown the server
-		quit := make(chan os.Signal, 1)
-		signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
-		<-quit
+			listener, err := net.Listen("tcp", address)
+			if err != nil {
+				return fmt.Errorf("failed to listen on HTTP port: %w", err)
+			}
 
-		app.Logger.Info("Shutdown signal received").Send()
+			// Serve HTTPS when USING_SECURE is set, certificates are reloaded from disk when they change
+			if app.Config.Http.UsingSecure {
+				reloader, err := certificate.NewReloader(tlsOptions(app))
+				if err != nil {
+					listener.Close()
+					return fmt.Errorf("failed to load HTTP TLS certificate: %w", err)
+				}
+				listener = tls.NewListener(listener, reloader.TLSConfig())
+			}
+			e.Listener = listener
 
-		// Graceful shutdown with timeout
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
+			return nil
+		},
+		Run: func() error {
+			app.Logger.Info(fmt.Sprintf("HTTP server starting on %s", app.Config.Http.URL)).Send()
+			if err := e.Start(address); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			return e.Shutdown(ctx)
+		},
+	}
+}
 
-		// Shutdown HTTP server
-		if err := e.Shutdown(ctx); err != nil {
-			app.Logger.Error(fmt.Sprintf("Failed to shutdown HTTP server: %v", err)).Send()
-		} else {
-			app.Logger.Info("HTTP server shutdown successfully").Send()
-		}
+// tlsOptions maps the HTTP TLS configuration to certificate reloader options
+func tlsOptions(app App) certificate.Options {
+	cfg := app.Config.Http.TLS
 
-		app.Logger.Info("Application shutdown completed").Send()
-	}()
+	return certificate.Options{
+		CertFile:       cfg.CertFile,
+		KeyFile:        cfg.KeyFile,
+		ClientCAFile:   cfg.ClientCAFile,
+		ClientAuth:     cfg.ClientAuth,
+		MinVersion:     cfg.MinVersion,
+		ReloadInterval: cfg.ReloadInterval,
+		OnReload: func(err error) {
+			if err != nil {
+				app.Logger.Error("Failed to reload HTTP TLS certificate, keeping the previous one").ErrorData(err).Send()
+				return
+			}
+			app.Logger.Info("HTTP TLS certificate reloaded").Send()
+		},
+	}
 }
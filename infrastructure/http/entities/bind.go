@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.risoftinc.com/goresponse"
+	"go.risoftinc.com/xarch/constant"
+	"go.risoftinc.com/xarch/utils/validator"
+)
+
+// Bind decodes the path, query and body parameters of a request and validates them with the echo validator,
+// failures are bad_request and validation_failed responses naming the invalid fields
+func Bind(ctx echo.Context, req any) error {
+	ctxReq := ctx.Request().Context()
+
+	if err := ctx.Bind(req); err != nil {
+		return goresponse.NewResponseBuilder(constant.ErrorBadRequest).
+			WithContext(ctxReq).
+			SetError(err).
+			ToError()
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		var fields []string
+		var validationErr *validator.ValidationErrors
+		if errors.As(err, &validationErr) {
+			fields = validationErr.Fields()
+		}
+
+		return goresponse.NewResponseBuilder(constant.ErrorValidationFailed).
+			WithContext(ctxReq).
+			SetData("field", strings.Join(fields, ", ")).
+			SetError(err).
+			ToError()
+	}
+
+	return nil
+}
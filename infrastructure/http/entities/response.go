@@ -82,9 +82,13 @@ func (e *Entities) ResponseFormater(ctx echo.Context, res *goresponse.ResponseBu
 		})
 	}
 
+	// List responses carry their page in SetData("pagination", response.Pagination{...})
+	pagination, _ := resBuild.Data["pagination"].(response.Pagination)
+
 	return ctx.JSON(resBuild.Code, response.Response{
 		Meta: response.Meta{
-			Message: resBuild.Message,
+			Message:    resBuild.Message,
+			Pagination: pagination,
 		},
 		Data: resBuild.Data["data"],
 	})
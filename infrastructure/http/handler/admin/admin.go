@@ -0,0 +1,59 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/goresponse"
+	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/constant"
+	"go.risoftinc.com/xarch/infrastructure/http/entities"
+)
+
+type (
+	IAdminHandler interface {
+		Config(ctx echo.Context) error
+	}
+	AdminHandler struct {
+		logger   gologger.Logger
+		entities entities.IEntities
+		cfg      config.Config
+	}
+)
+
+func NewAdminHandlers(
+	logger gologger.Logger,
+	entities entities.IEntities,
+	cfg config.Config,
+) IAdminHandler {
+	return &AdminHandler{
+		logger:   logger,
+		entities: entities,
+		cfg:      cfg,
+	}
+}
+
+// Config returns the effective configuration with secrets masked.
+// Use ?format=yaml for a plain YAML document instead of the JSON response envelope.
+func (handler AdminHandler) Config(ctx echo.Context) error {
+	ctxReq := ctx.Request().Context()
+
+	handler.logger.WithContext(ctxReq).Info("Effective configuration requested").Send()
+
+	if ctx.QueryParam("format") == config.FormatYAML {
+		out, err := handler.cfg.Dump(config.FormatYAML)
+		if err != nil {
+			return handler.entities.ResponseFormaterError(ctx, err)
+		}
+		return ctx.Blob(http.StatusOK, "application/yaml", out)
+	}
+
+	return handler.entities.ResponseFormater(ctx,
+		goresponse.NewResponseBuilder(constant.IsResponseRetrieved).
+			WithContext(ctxReq).SetData("data", map[string]any{
+			"config":  handler.cfg.Map(),
+			"sources": config.Sources(),
+		}),
+	)
+}
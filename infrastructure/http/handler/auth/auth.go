@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/goresponse"
+	"go.risoftinc.com/xarch/constant"
+	authModels "go.risoftinc.com/xarch/domain/models/auth"
+	authServices "go.risoftinc.com/xarch/domain/services/auth"
+	"go.risoftinc.com/xarch/infrastructure/http/entities"
+	"go.risoftinc.com/xarch/utils/token"
+)
+
+type (
+	IAuthHandler interface {
+		Login(ctx echo.Context) error
+		Refresh(ctx echo.Context) error
+		Logout(ctx echo.Context) error
+	}
+	AuthHandler struct {
+		logger       gologger.Logger
+		entities     entities.IEntities
+		authServices authServices.IAuthServices
+	}
+)
+
+func NewAuthHandlers(
+	logger gologger.Logger,
+	entities entities.IEntities,
+	authServices authServices.IAuthServices,
+) IAuthHandler {
+	return &AuthHandler{
+		logger:       logger,
+		entities:     entities,
+		authServices: authServices,
+	}
+}
+
+func (handler AuthHandler) Login(ctx echo.Context) error {
+	ctxReq := ctx.Request().Context()
+
+	var req authModels.LoginRequest
+	if err := entities.Bind(ctx, &req); err != nil {
+		return handler.entities.ResponseFormaterError(ctx, err)
+	}
+
+	tokens, err := handler.authServices.Login(ctxReq, req)
+	if err != nil {
+		return handler.entities.ResponseFormaterError(ctx, err)
+	}
+
+	return handler.entities.ResponseFormater(ctx,
+		goresponse.NewResponseBuilder(constant.IsResponseSuccess).
+			WithContext(ctxReq).SetData("data", tokens),
+	)
+}
+
+func (handler AuthHandler) Refresh(ctx echo.Context) error {
+	ctxReq := ctx.Request().Context()
+
+	var req authModels.RefreshRequest
+	if err := entities.Bind(ctx, &req); err != nil {
+		return handler.entities.ResponseFormaterError(ctx, err)
+	}
+
+	tokens, err := handler.authServices.Refresh(ctxReq, req)
+	if err != nil {
+		return handler.entities.ResponseFormaterError(ctx, err)
+	}
+
+	return handler.entities.ResponseFormater(ctx,
+		goresponse.NewResponseBuilder(constant.IsResponseSuccess).
+			WithContext(ctxReq).SetData("data", tokens),
+	)
+}
+
+// Logout runs behind AuthMiddleware, the access token of the request is revoked
+func (handler AuthHandler) Logout(ctx echo.Context) error {
+	ctxReq := ctx.Request().Context()
+
+	claims, ok := token.ClaimsFromContext(ctxReq)
+	if !ok {
+		return handler.entities.ResponseFormaterError(ctx,
+			goresponse.NewResponseBuilder(constant.ErrorTokenMissing).
+				WithContext(ctxReq).
+				SetError(token.ErrMissing).
+				ToError(),
+		)
+	}
+
+	var req authModels.LogoutRequest
+	if err := entities.Bind(ctx, &req); err != nil {
+		return handler.entities.ResponseFormaterError(ctx, err)
+	}
+
+	if err := handler.authServices.Logout(ctxReq, claims, req); err != nil {
+		return handler.entities.ResponseFormaterError(ctx, err)
+	}
+
+	return handler.entities.ResponseFormater(ctx,
+		goresponse.NewResponseBuilder(constant.IsResponseSuccess).
+			WithContext(ctxReq),
+	)
+}
@@ -1,22 +1,29 @@
 package health
 
 import (
+	"errors"
+
 	"github.com/labstack/echo/v4"
 	"go.risoftinc.com/gologger"
 	"go.risoftinc.com/goresponse"
 	"go.risoftinc.com/xarch/constant"
 	healthServices "go.risoftinc.com/xarch/domain/services/health"
 	"go.risoftinc.com/xarch/infrastructure/http/entities"
+	"go.risoftinc.com/xarch/utils/healthcheck"
 )
 
 type (
 	IHealthHandler interface {
 		Metric(ctx echo.Context) error
+		Livez(ctx echo.Context) error
+		Readyz(ctx echo.Context) error
+		Startupz(ctx echo.Context) error
 	}
 	HealthHandler struct {
 		logger         gologger.Logger
 		entities       entities.IEntities
 		healthServices healthServices.IHealthServices
+		probes         healthcheck.IProbes
 	}
 )
 
@@ -24,11 +31,13 @@ func NewHealthHandlers(
 	logger gologger.Logger,
 	entities entities.IEntities,
 	healthServices healthServices.IHealthServices,
+	probes healthcheck.IProbes,
 ) IHealthHandler {
 	return &HealthHandler{
 		logger:         logger,
 		entities:       entities,
 		healthServices: healthServices,
+		probes:         probes,
 	}
 }
 
@@ -47,3 +56,38 @@ func (handler HealthHandler) Metric(ctx echo.Context) error {
 			WithContext(ctxReq).SetData("data", metric),
 	)
 }
+
+// Livez answers as long as the process can serve requests, dependencies are never checked
+// so a database outage does not restart the pod.
+func (handler HealthHandler) Livez(ctx echo.Context) error {
+	return handler.probe(ctx, "alive", true, "")
+}
+
+// Readyz fails before startup completes, once graceful shutdown starts and while a critical dependency is down
+func (handler HealthHandler) Readyz(ctx echo.Context) error {
+	ready, reason := handler.probes.Ready(ctx.Request().Context())
+	return handler.probe(ctx, "ready", ready, reason)
+}
+
+// Startupz fails until every component has started
+func (handler HealthHandler) Startupz(ctx echo.Context) error {
+	return handler.probe(ctx, "started", handler.probes.Started(), healthcheck.ReasonStarting)
+}
+
+func (handler HealthHandler) probe(ctx echo.Context, status string, ok bool, reason string) error {
+	ctxReq := ctx.Request().Context()
+
+	if !ok {
+		return handler.entities.ResponseFormaterError(ctx,
+			goresponse.NewResponseBuilder(constant.ErrorServiceUnavailable).
+				WithContext(ctxReq).
+				SetError(errors.New(reason)).
+				ToError(),
+		)
+	}
+
+	return handler.entities.ResponseFormater(ctx,
+		goresponse.NewResponseBuilder(constant.IsResponseSuccess).
+			WithContext(ctxReq).SetData("data", map[string]string{"status": status}),
+	)
+}
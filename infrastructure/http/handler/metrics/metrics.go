@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"github.com/labstack/echo/v4"
+	collector "go.risoftinc.com/xarch/infrastructure/metrics"
+)
+
+type (
+	IMetricsHandler interface {
+		Metrics(ctx echo.Context) error
+	}
+	MetricsHandler struct {
+		metrics *collector.Metrics
+	}
+)
+
+func NewMetricsHandlers(metrics *collector.Metrics) IMetricsHandler {
+	return &MetricsHandler{
+		metrics: metrics,
+	}
+}
+
+// Metrics serves the Prometheus exposition format, it answers 404 when METRICS_ENABLED is false
+func (handler MetricsHandler) Metrics(ctx echo.Context) error {
+	if !handler.metrics.Enabled() {
+		return echo.ErrNotFound
+	}
+
+	handler.metrics.Handler().ServeHTTP(ctx.Response(), ctx.Request())
+	return nil
+}
@@ -0,0 +1,170 @@
+package user
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/goresponse"
+	"go.risoftinc.com/xarch/constant"
+	userModels "go.risoftinc.com/xarch/domain/models/user"
+	userServices "go.risoftinc.com/xarch/domain/services/user"
+	"go.risoftinc.com/xarch/infrastructure/http/entities"
+	"go.risoftinc.com/xarch/utils/query"
+)
+
+type (
+	IUserHandler interface {
+		List(ctx echo.Context) error
+		Get(ctx echo.Context) error
+		Create(ctx echo.Context) error
+		Update(ctx echo.Context) error
+		Delete(ctx echo.Context) error
+	}
+	UserHandler struct {
+		logger       gologger.Logger
+		entities     entities.IEntities
+		userServices userServices.IUserServices
+	}
+)
+
+func NewUserHandlers(
+	logger gologger.Logger,
+	entities entities.IEntities,
+	userServices userServices.IUserServices,
+) IUserHandler {
+	return &UserHandler{
+		logger:       logger,
+		entities:     entities,
+		userServices: userServices,
+	}
+}
+
+// List answers one page of users, ?filter[username][like]=ali&sort=-created_at&page=1&per_page=10
+func (handler UserHandler) List(ctx echo.Context) error {
+	ctxReq := ctx.Request().Context()
+
+	spec, err := query.FromValues(ctx.QueryParams())
+	if err != nil {
+		var field string
+		var queryErr *query.Error
+		if errors.As(err, &queryErr) {
+			field = queryErr.Field
+		}
+		return handler.entities.ResponseFormaterError(ctx,
+			goresponse.NewResponseBuilder(constant.ErrorValidationFailed).
+				WithContext(ctxReq).
+				SetData("field", field).
+				SetError(err).
+				ToError(),
+		)
+	}
+
+	users, pagination, err := handler.userServices.List(ctxReq, spec)
+	if err != nil {
+		return handler.entities.ResponseFormaterError(ctx, err)
+	}
+
+	return handler.entities.ResponseFormater(ctx,
+		goresponse.NewResponseBuilder(constant.IsResponseDataRetrieved).
+			WithContext(ctxReq).
+			SetData("count", len(users)).
+			SetData("data", users).
+			SetData("pagination", pagination),
+	)
+}
+
+func (handler UserHandler) Get(ctx echo.Context) error {
+	ctxReq := ctx.Request().Context()
+
+	id, err := handler.id(ctx)
+	if err != nil {
+		return handler.entities.ResponseFormaterError(ctx, err)
+	}
+
+	user, err := handler.userServices.Get(ctxReq, id)
+	if err != nil {
+		return handler.entities.ResponseFormaterError(ctx, err)
+	}
+
+	return handler.entities.ResponseFormater(ctx,
+		goresponse.NewResponseBuilder(constant.IsResponseRetrieved).
+			WithContext(ctxReq).SetData("data", user),
+	)
+}
+
+func (handler UserHandler) Create(ctx echo.Context) error {
+	ctxReq := ctx.Request().Context()
+
+	var req userModels.CreateUserRequest
+	if err := entities.Bind(ctx, &req); err != nil {
+		return handler.entities.ResponseFormaterError(ctx, err)
+	}
+
+	user, err := handler.userServices.Create(ctxReq, req)
+	if err != nil {
+		return handler.entities.ResponseFormaterError(ctx, err)
+	}
+
+	return handler.entities.ResponseFormater(ctx,
+		goresponse.NewResponseBuilder(constant.IsResponseUserCreated).
+			WithContext(ctxReq).
+			SetData("username", user.Username).
+			SetData("data", user),
+	)
+}
+
+func (handler UserHandler) Update(ctx echo.Context) error {
+	ctxReq := ctx.Request().Context()
+
+	var req userModels.UpdateUserRequest
+	if err := entities.Bind(ctx, &req); err != nil {
+		return handler.entities.ResponseFormaterError(ctx, err)
+	}
+
+	user, err := handler.userServices.Update(ctxReq, req)
+	if err != nil {
+		return handler.entities.ResponseFormaterError(ctx, err)
+	}
+
+	return handler.entities.ResponseFormater(ctx,
+		goresponse.NewResponseBuilder(constant.IsResponseUserUpdated).
+			WithContext(ctxReq).
+			SetData("username", user.Username).
+			SetData("data", user),
+	)
+}
+
+func (handler UserHandler) Delete(ctx echo.Context) error {
+	ctxReq := ctx.Request().Context()
+
+	id, err := handler.id(ctx)
+	if err != nil {
+		return handler.entities.ResponseFormaterError(ctx, err)
+	}
+
+	user, err := handler.userServices.Delete(ctxReq, id)
+	if err != nil {
+		return handler.entities.ResponseFormaterError(ctx, err)
+	}
+
+	return handler.entities.ResponseFormater(ctx,
+		goresponse.NewResponseBuilder(constant.IsResponseUserDeleted).
+			WithContext(ctxReq).
+			SetData("username", user.Username),
+	)
+}
+
+// id reads the :id path parameter
+func (handler UserHandler) id(ctx echo.Context) (uint, error) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		return 0, goresponse.NewResponseBuilder(constant.ErrorValidationFailed).
+			WithContext(ctx.Request().Context()).
+			SetData("field", "id").
+			SetError(errors.New("id must be a positive number")).
+			ToError()
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,210 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/infrastructure/tracing"
+)
+
+const redactedValue = "******"
+
+type (
+	IAccessLogMiddleware interface {
+		AccessLog() echo.MiddlewareFunc
+	}
+	AccessLogMiddleware struct {
+		logger        gologger.Logger
+		cfg           config.AccessLogConfig
+		skipPaths     map[string]bool
+		redactHeaders map[string]bool
+		redactFields  map[string]bool
+	}
+)
+
+func NewAccessLogMiddleware(logger gologger.Logger, cfg config.Config) IAccessLogMiddleware {
+	am := &AccessLogMiddleware{
+		logger:        logger,
+		cfg:           cfg.AccessLog,
+		skipPaths:     make(map[string]bool),
+		redactHeaders: make(map[string]bool),
+		redactFields:  make(map[string]bool),
+	}
+	for _, path := range cfg.AccessLog.SkipPaths {
+		am.skipPaths[path] = true
+	}
+	for _, header := range cfg.AccessLog.RedactHeaders {
+		am.redactHeaders[http.CanonicalHeaderKey(header)] = true
+	}
+	for _, field := range cfg.AccessLog.RedactFields {
+		am.redactFields[strings.ToLower(field)] = true
+	}
+
+	return am
+}
+
+// AccessLog writes one structured line per request after the response is written.
+// It runs after ContextMiddleware so the line carries the request ID. Successful and
+// client error requests are sampled with ACCESS_LOG_SAMPLE_RATIO, server errors are always logged.
+func (am AccessLogMiddleware) AccessLog() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if !am.cfg.Enabled {
+			return next
+		}
+
+		return func(c echo.Context) error {
+			request := c.Request()
+			if am.skipPaths[c.Path()] || am.skipPaths[request.URL.Path] {
+				return next(c)
+			}
+
+			body := &countingReader{ReadCloser: request.Body}
+			var captured []byte
+			if am.cfg.LogBody && isJSON(request.Header.Get(echo.HeaderContentType)) {
+				captured = body.capture(am.cfg.BodyMaxBytes)
+			}
+			request.Body = body
+
+			started := time.Now()
+			err := next(c)
+			latency := time.Since(started)
+
+			status := statusCode(c, err)
+			if status < http.StatusInternalServerError && !am.sampled() {
+				return err
+			}
+
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+
+			ctx := request.Context()
+			requestID, _ := c.Get(string(gologger.RequestIDKey)).(string)
+			traceID, spanID := tracing.IDs(ctx)
+			entry := am.entry(ctx, status).
+				Data("method", request.Method).
+				Data("route", route).
+				Data("path", request.URL.Path).
+				Data("status", status).
+				Data("latency_ms", float64(latency.Microseconds())/1000).
+				Data("bytes_in", max(request.ContentLength, body.count)).
+				Data("bytes_out", c.Response().Size).
+				Data("client_ip", c.RealIP()).
+				Data("user_agent", request.UserAgent()).
+				Data("request_id", requestID).
+				Data("trace_id", traceID).
+				Data("span_id", spanID)
+
+			if am.cfg.LogHeaders {
+				entry = entry.Data("headers", am.headers(request.Header))
+			}
+			if captured != nil {
+				entry = entry.Data("body", am.body(captured, am.cfg.BodyMaxBytes))
+			}
+			if err != nil {
+				entry = entry.Data("error", err.Error())
+			}
+			entry.Send()
+
+			return err
+		}
+	}
+}
+
+func (am AccessLogMiddleware) entry(ctx context.Context, status int) *gologger.Entry {
+	logger := am.logger.WithContext(ctx)
+	switch {
+	case status >= http.StatusInternalServerError:
+		return logger.Error("HTTP request completed")
+	case status >= http.StatusBadRequest:
+		return logger.Warn("HTTP request completed")
+	default:
+		return logger.Info("HTTP request completed")
+	}
+}
+
+func (am AccessLogMiddleware) sampled() bool {
+	return am.cfg.SampleRatio >= 1 || rand.Float64() < am.cfg.SampleRatio
+}
+
+// headers returns the request headers with the values of ACCESS_LOG_REDACT_HEADERS masked
+func (am AccessLogMiddleware) headers(header http.Header) map[string]string {
+	result := make(map[string]string, len(header))
+	for name, values := range header {
+		if am.redactHeaders[http.CanonicalHeaderKey(name)] {
+			result[name] = redactedValue
+			continue
+		}
+		result[name] = strings.Join(values, ", ")
+	}
+	return result
+}
+
+// body returns the JSON request body with the ACCESS_LOG_REDACT_FIELDS masked at any depth.
+// Bodies larger than the limit or that are not valid JSON are not logged, they could hold secrets.
+func (am AccessLogMiddleware) body(captured []byte, limit int) any {
+	if len(captured) > limit {
+		return "[truncated]"
+	}
+
+	var payload any
+	if err := json.Unmarshal(captured, &payload); err != nil {
+		return "[invalid json]"
+	}
+	return am.redact(payload)
+}
+
+func (am AccessLogMiddleware) redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if am.redactFields[strings.ToLower(key)] {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = am.redact(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = am.redact(item)
+		}
+	}
+	return value
+}
+
+func isJSON(contentType string) bool {
+	return strings.HasPrefix(contentType, echo.MIMEApplicationJSON)
+}
+
+// countingReader counts the request bytes read by the handler
+type countingReader struct {
+	io.ReadCloser
+	count int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
+// capture reads up to limit+1 bytes ahead for the log and replays them to the handler,
+// the extra byte tells a body at the limit from a larger one
+func (r *countingReader) capture(limit int) []byte {
+	captured, _ := io.ReadAll(io.LimitReader(r.ReadCloser, int64(limit)+1))
+	r.ReadCloser = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(captured), r.ReadCloser), r.ReadCloser}
+	return captured
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+
+	"github.com/labstack/echo/v4"
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/goresponse"
+	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/constant"
+	"go.risoftinc.com/xarch/infrastructure/http/entities"
+	"go.risoftinc.com/xarch/utils/token"
+)
+
+type (
+	IAdminMiddleware interface {
+		AdminAuth() echo.MiddlewareFunc
+	}
+	AdminMiddleware struct {
+		logger   gologger.Logger
+		entities entities.IEntities
+		cfg      config.AdminConfig
+	}
+)
+
+func NewAdminMiddleware(logger gologger.Logger, entities entities.IEntities, cfg config.Config) IAdminMiddleware {
+	return &AdminMiddleware{
+		logger:   logger,
+		entities: entities,
+		cfg:      cfg.Admin,
+	}
+}
+
+// AdminAuth protects admin routes with the ADMIN_TOKEN bearer token.
+// When admin routes are disabled they answer 404 as if they did not exist.
+func (am AdminMiddleware) AdminAuth() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !am.cfg.Enabled {
+				return echo.ErrNotFound
+			}
+
+			ctx := c.Request().Context()
+			adminToken := token.BearerToken(c.Request().Header.Get(echo.HeaderAuthorization))
+
+			if adminToken == "" || subtle.ConstantTimeCompare([]byte(adminToken), []byte(am.cfg.Token)) != 1 {
+				am.logger.WithContext(ctx).Warn("Rejected admin request").
+					Data("path", c.Path()).
+					Data("ip", c.RealIP()).
+					Send()
+
+				return am.entities.ResponseFormaterError(c,
+					goresponse.NewResponseBuilder(constant.ErrorUnauthorized).
+						WithContext(ctx).
+						SetError(errors.New("invalid admin token")).
+						ToError(),
+				)
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/goresponse"
+	"go.risoftinc.com/xarch/constant"
+	authServices "go.risoftinc.com/xarch/domain/services/auth"
+	"go.risoftinc.com/xarch/infrastructure/http/entities"
+	"go.risoftinc.com/xarch/utils/token"
+)
+
+// ClaimsKey stores the verified claims in the echo context
+const ClaimsKey = "claims"
+
+type (
+	IAuthMiddleware interface {
+		Authenticate() echo.MiddlewareFunc
+	}
+	AuthMiddleware struct {
+		logger       gologger.Logger
+		entities     entities.IEntities
+		verifier     token.IVerifier
+		authServices authServices.IAuthServices
+	}
+)
+
+func NewAuthMiddleware(logger gologger.Logger, entities entities.IEntities, verifier token.IVerifier, authServices authServices.IAuthServices) IAuthMiddleware {
+	return &AuthMiddleware{
+		logger:       logger,
+		entities:     entities,
+		verifier:     verifier,
+		authServices: authServices,
+	}
+}
+
+// Authenticate requires a valid bearer access token and puts its claims in the request context,
+// read them with token.ClaimsFromContext(ctx) or c.Get(ClaimsKey)
+func (am AuthMiddleware) Authenticate() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+
+			claims, err := am.verifier.Verify(token.BearerToken(c.Request().Header.Get(echo.HeaderAuthorization)))
+			if err == nil {
+				// Tokens denylisted by a logout are rejected until they expire
+				revoked, lookupErr := am.authServices.IsRevoked(ctx, claims)
+				if lookupErr != nil {
+					am.logger.WithContext(ctx).Error("Failed to check the token denylist").ErrorData(lookupErr).Send()
+					return am.entities.ResponseFormaterError(c,
+						goresponse.NewResponseBuilder(constant.ErrorServiceUnavailable).
+							WithContext(ctx).
+							SetError(lookupErr).
+							ToError(),
+					)
+				}
+				if revoked {
+					err = token.ErrRevoked
+				}
+			}
+			if err != nil {
+				am.logger.WithContext(ctx).Warn("Rejected unauthenticated request").
+					Data("path", c.Path()).
+					Data("ip", c.RealIP()).
+					Data("reason", err.Error()).
+					Send()
+
+				return am.entities.ResponseFormaterError(c,
+					goresponse.NewResponseBuilder(token.ErrorKey(err)).
+						WithContext(ctx).
+						SetError(err).
+						ToError(),
+				)
+			}
+
+			c.Set(ClaimsKey, claims)
+			c.SetRequest(c.Request().WithContext(token.WithClaims(ctx, claims)))
+
+			return next(c)
+		}
+	}
+}
@@ -1,16 +1,24 @@
 package middleware
 
 import (
+	"context"
+
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.risoftinc.com/gologger"
 	"go.risoftinc.com/goresponse"
 	"go.risoftinc.com/xarch/constant"
+	"go.risoftinc.com/xarch/infrastructure/tracing"
 )
 
 const (
 	RequestIDHeader = "X-Request-ID"
 	LanguageHeader  = "X-Language"
+
+	// RequestIDAttribute links a span to the log lines of its request
+	RequestIDAttribute = "request.id"
 )
 
 type (
@@ -36,10 +44,11 @@ func (rm ContextMiddleware) ContextMiddleware() echo.MiddlewareFunc {
 			requestID := c.Request().Header.Get(RequestIDHeader)
 			language := c.Request().Header.Get(LanguageHeader)
 
-			// If no request ID in header, generate a new UUID
+			// If no request ID in header, reuse the trace ID or generate a new UUID
 			if requestID == "" {
-				requestID = uuid.New().String()
+				requestID = newRequestID(c.Request().Context())
 			}
+			trace.SpanFromContext(c.Request().Context()).SetAttributes(attribute.String(RequestIDAttribute, requestID))
 
 			// If no language in header, set default language
 			if language == "" {
@@ -66,3 +75,11 @@ func (rm ContextMiddleware) ContextMiddleware() echo.MiddlewareFunc {
 		}
 	}
 }
+
+// newRequestID uses the trace ID when the request is traced, so log lines and spans share one ID
+func newRequestID(ctx context.Context) string {
+	if traceID, _ := tracing.IDs(ctx); traceID != "" {
+		return traceID
+	}
+	return uuid.New().String()
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.risoftinc.com/xarch/infrastructure/metrics"
+)
+
+// MetricsPath is the route of the Prometheus endpoint, it is not recorded itself
+const MetricsPath = "/metrics"
+
+type (
+	IMetricsMiddleware interface {
+		Metrics() echo.MiddlewareFunc
+	}
+	MetricsMiddleware struct {
+		metrics *metrics.Metrics
+	}
+)
+
+func NewMetricsMiddleware(metrics *metrics.Metrics) IMetricsMiddleware {
+	return &MetricsMiddleware{
+		metrics: metrics,
+	}
+}
+
+// Metrics records the count and latency of every request per route template (e.g. /users/:id)
+// and the status code written by the goresponse formatter
+func (mm MetricsMiddleware) Metrics() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if !mm.metrics.Enabled() {
+			return next
+		}
+
+		return func(c echo.Context) error {
+			started := time.Now()
+			err := next(c)
+
+			route := c.Path()
+			if route == MetricsPath {
+				return err
+			}
+			if route == "" {
+				route = "unmatched"
+			}
+
+			mm.metrics.ObserveHttp(c.Request().Method, route, statusCode(c, err), time.Since(started))
+
+			return err
+		}
+	}
+}
+
+// statusCode returns the code that will be written for a request, errors are written by echo after the middleware returns
+func statusCode(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.risoftinc.com/xarch/infrastructure/tracing"
+)
+
+type (
+	ITracingMiddleware interface {
+		Tracing() echo.MiddlewareFunc
+	}
+	TracingMiddleware struct {
+		tracing *tracing.Tracing
+	}
+)
+
+func NewTracingMiddleware(tracing *tracing.Tracing) ITracingMiddleware {
+	return &TracingMiddleware{
+		tracing: tracing,
+	}
+}
+
+// Tracing continues the trace of the traceparent header, or starts a new one, with a server span
+// named after the route template (e.g. GET /users/:id). It runs before ContextMiddleware so the
+// request ID and every log line can refer to the trace. With tracing disabled the incoming trace
+// context is only extracted, logs still carry the trace of the caller.
+func (tm TracingMiddleware) Tracing() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if tm.tracing == nil {
+			return next
+		}
+		if !tm.tracing.Enabled() {
+			return func(c echo.Context) error {
+				request := c.Request()
+				ctx := tm.tracing.Propagator().Extract(request.Context(), propagation.HeaderCarrier(request.Header))
+				c.SetRequest(request.WithContext(ctx))
+				return next(c)
+			}
+		}
+
+		return func(c echo.Context) error {
+			route := c.Path()
+			if route == MetricsPath {
+				return next(c)
+			}
+			if route == "" {
+				route = "unmatched"
+			}
+
+			request := c.Request()
+			ctx := tm.tracing.Propagator().Extract(request.Context(), propagation.HeaderCarrier(request.Header))
+			ctx, span := tm.tracing.Tracer().Start(ctx, fmt.Sprintf("%s %s", request.Method, route),
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", request.Method),
+					attribute.String("http.route", route),
+					attribute.String("url.path", request.URL.Path),
+					attribute.String("client.address", c.RealIP()),
+				),
+			)
+			defer span.End()
+
+			c.SetRequest(request.WithContext(ctx))
+
+			err := next(c)
+
+			code := statusCode(c, err)
+			span.SetAttributes(attribute.Int("http.response.status_code", code))
+			if code >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(code))
+			}
+			if err != nil {
+				span.RecordError(err)
+			}
+
+			return err
+		}
+	}
+}
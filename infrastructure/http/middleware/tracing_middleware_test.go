@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/infrastructure/tracing"
+)
+
+func TestTracingPropagatesTraceContext(t *testing.T) {
+	const (
+		traceID  = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentID = "00f067aa0ba902b7"
+	)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer provider.Shutdown(context.Background())
+
+	engine := echo.New()
+	engine.Use(NewTracingMiddleware(tracing.NewTracingWithProvider(provider)).Tracing())
+	engine.Use(NewContextMiddleware(gologger.Logger{}).ContextMiddleware())
+
+	var handlerSpan trace.SpanContext
+	engine.GET("/users/:id", func(c echo.Context) error {
+		handlerSpan = trace.SpanContextFromContext(c.Request().Context())
+		return c.NoContent(http.StatusInternalServerError)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	request.Header.Set("traceparent", "00-"+traceID+"-"+parentID+"-01")
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, request)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+
+	if span.Name != "GET /users/:id" {
+		t.Errorf("span name = %q, want GET /users/:id", span.Name)
+	}
+	if span.SpanContext.TraceID().String() != traceID || span.Parent.SpanID().String() != parentID {
+		t.Errorf("span does not continue the incoming trace: trace %s parent %s", span.SpanContext.TraceID(), span.Parent.SpanID())
+	}
+	if handlerSpan.SpanID() != span.SpanContext.SpanID() {
+		t.Errorf("handler context does not carry the server span")
+	}
+	// Without X-Request-ID the trace ID becomes the request ID
+	if got := recorder.Header().Get(RequestIDHeader); got != traceID {
+		t.Errorf("%s = %q, want the trace ID", RequestIDHeader, got)
+	}
+	if span.Status.Code != codes.Error {
+		t.Errorf("span status = %v, want Error for a 500 response", span.Status)
+	}
+}
+
+func TestTracingDisabledKeepsTraceContext(t *testing.T) {
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	disabled, err := tracing.NewTracing(config.TracingConfig{Exporter: "none"})
+	if err != nil {
+		t.Fatalf("NewTracing() error = %v", err)
+	}
+
+	engine := echo.New()
+	engine.Use(NewTracingMiddleware(disabled).Tracing())
+	engine.Use(NewContextMiddleware(gologger.Logger{}).ContextMiddleware())
+	engine.GET("/users", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/users", nil)
+	request.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, request)
+
+	if got := recorder.Header().Get(RequestIDHeader); got != traceID {
+		t.Errorf("%s = %q, want the incoming trace ID", RequestIDHeader, got)
+	}
+}
+
+func TestTracingKeepsSuppliedRequestID(t *testing.T) {
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	provider := sdktrace.NewTracerProvider()
+	defer provider.Shutdown(context.Background())
+
+	engine := echo.New()
+	engine.Use(NewTracingMiddleware(tracing.NewTracingWithProvider(provider)).Tracing())
+	engine.Use(NewContextMiddleware(gologger.Logger{}).ContextMiddleware())
+
+	var logID any
+	engine.GET("/users", func(c echo.Context) error {
+		logID = c.Request().Context().Value(gologger.RequestIDKey)
+		return c.NoContent(http.StatusOK)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/users", nil)
+	request.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	request.Header.Set(RequestIDHeader, "req-1")
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, request)
+
+	// The trace ID is a separate log field, the request ID of the caller stays the one that is logged
+	if logID != "req-1" {
+		t.Errorf("logged request ID = %v, want req-1", logID)
+	}
+	if got := recorder.Header().Get(RequestIDHeader); got != "req-1" {
+		t.Errorf("%s = %q, want req-1", RequestIDHeader, got)
+	}
+}
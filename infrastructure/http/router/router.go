@@ -4,6 +4,7 @@ import (
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
 	dep "go.risoftinc.com/xarch/infrastructure/http"
+	"go.risoftinc.com/xarch/infrastructure/http/middleware"
 	"go.risoftinc.com/xarch/utils/validator"
 )
 
@@ -13,12 +14,43 @@ func Routers(dep *dep.Dependencies) *echo.Echo {
 	// Add custom validator
 	engine.Validator = validator.NewCustomValidator()
 
+	// Tracing runs first so the request ID and log lines can refer to the trace
+	engine.Use(dep.TracingMiddleware.Tracing())
+
 	// Add request ID middleware globally
 	engine.Use(dep.Middlewares.ContextMiddleware())
+	engine.Use(dep.AccessLogMiddleware.AccessLog())
+	engine.Use(dep.MetricsMiddleware.Metrics())
 	engine.Use(echoMiddleware.Recover())
 
 	// Public routes
 	engine.GET("/health", dep.HealthHandlers.Metric)
 
+	// Probes, /health checks every dependency and should not be used for liveness
+	engine.GET("/livez", dep.HealthHandlers.Livez)
+	engine.GET("/readyz", dep.HealthHandlers.Readyz)
+	engine.GET("/startupz", dep.HealthHandlers.Startupz)
+
+	// Prometheus metrics, answers 404 unless METRICS_ENABLED is true
+	engine.GET(middleware.MetricsPath, dep.MetricsHandlers.Metrics)
+
+	// Auth routes, logout revokes the access token of the request
+	auth := engine.Group("/auth")
+	auth.POST("/login", dep.AuthHandlers.Login)
+	auth.POST("/refresh", dep.AuthHandlers.Refresh)
+	auth.POST("/logout", dep.AuthHandlers.Logout, dep.AuthMiddleware.Authenticate())
+
+	// User routes, every route requires a token and declares its permission
+	users := engine.Group("/users", dep.AuthMiddleware.Authenticate())
+	users.GET("", dep.UserHandlers.List, dep.RBACMiddleware.RequirePermissions("users:read"))
+	users.GET("/:id", dep.UserHandlers.Get, dep.RBACMiddleware.RequirePermissions("users:read"))
+	users.POST("", dep.UserHandlers.Create, dep.RBACMiddleware.RequirePermissions("users:create"))
+	users.PUT("/:id", dep.UserHandlers.Update, dep.RBACMiddleware.RequirePermissions("users:update"))
+	users.DELETE("/:id", dep.UserHandlers.Delete, dep.RBACMiddleware.RequirePermissions("users:delete"))
+
+	// Admin routes, answer 404 unless ADMIN_ENABLED is true
+	admin := engine.Group("/admin", dep.AdminMiddleware.AdminAuth())
+	admin.GET("/config", dep.AdminHandlers.Config)
+
 	return engine
 }
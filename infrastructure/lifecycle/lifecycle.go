@@ -0,0 +1,261 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/xarch/config"
+)
+
+type (
+	// Component is a part of the application whose start and stop are controlled by the manager.
+	// Every hook is optional.
+	Component struct {
+		// Name identifies the component in logs, errors and DependsOn lists
+		Name string
+		// DependsOn lists components that must be started before and stopped after this one
+		DependsOn []string
+		// Start prepares the component and must return once it is ready to be used
+		Start func(ctx context.Context) error
+		// Run blocks while the component is working (e.g. serving requests).
+		// A non-nil error stops the whole application in order.
+		Run func() error
+		// Stop releases the component and should return before ctx is done
+		Stop func(ctx context.Context) error
+	}
+
+	IManager interface {
+		Register(components ...Component)
+		Run(ctx context.Context) error
+	}
+
+	Manager struct {
+		logger          gologger.Logger
+		startTimeout    time.Duration
+		shutdownTimeout time.Duration
+		signals         []os.Signal
+		components      []Component
+	}
+
+	// failure is reported by a running component that stopped unexpectedly
+	failure struct {
+		name string
+		err  error
+	}
+)
+
+func NewManager(cfg config.LifecycleConfig, logger gologger.Logger) IManager {
+	return &Manager{
+		logger:          logger,
+		startTimeout:    cfg.StartTimeout,
+		shutdownTimeout: cfg.ShutdownTimeout,
+		signals:         []os.Signal{os.Interrupt, syscall.SIGTERM},
+	}
+}
+
+// Register adds components to the manager. Registration order is kept for components
+// that do not depend on each other.
+func (m *Manager) Register(components ...Component) {
+	m.components = append(m.components, components...)
+}
+
+// Run starts all components in dependency order and blocks until a shutdown signal is received,
+// ctx is cancelled or a running component fails. Components are then stopped in reverse order
+// within the configured shutdown budget.
+func (m *Manager) Run(ctx context.Context) error {
+	ordered, err := m.resolveOrder()
+	if err != nil {
+		return err
+	}
+
+	ctx, stopSignal := signal.NotifyContext(ctx, m.signals...)
+	defer stopSignal()
+
+	failures := make(chan failure, len(ordered))
+	started := make([]Component, 0, len(ordered))
+
+	for _, component := range ordered {
+		if err := m.start(ctx, component); err != nil {
+			m.logger.Error(fmt.Sprintf("Failed to start %s", component.Name)).ErrorData(err).Send()
+			return errors.Join(fmt.Errorf("start %s: %w", component.Name, err), m.stop(started))
+		}
+		started = append(started, component)
+
+		if component.Run != nil {
+			go func(component Component) {
+				if err := component.Run(); err != nil {
+					failures <- failure{name: component.Name, err: err}
+				}
+			}(component)
+		}
+	}
+
+	m.logger.Info("Application started").Data("components", len(started)).Send()
+
+	var cause error
+	select {
+	case <-ctx.Done():
+		m.logger.Info("Shutdown signal received").Send()
+	case f := <-failures:
+		m.logger.Error(fmt.Sprintf("Component %s failed, shutting down", f.name)).ErrorData(f.err).Send()
+		cause = fmt.Errorf("%s: %w", f.name, f.err)
+	}
+
+	return errors.Join(cause, m.stop(started))
+}
+
+// start runs the start hook of a single component bounded by the start timeout
+func (m *Manager) start(ctx context.Context, component Component) error {
+	if component.Start == nil {
+		return nil
+	}
+
+	if m.startTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.startTimeout)
+		defer cancel()
+	}
+
+	m.logger.Debug(fmt.Sprintf("Starting %s", component.Name)).Send()
+
+	return component.Start(ctx)
+}
+
+// stop stops the given components in reverse order. All components share one shutdown budget,
+// a component that does not return in time is reported as blocked and the ones after it, which
+// never get a live context, are reported as skipped without calling their hook.
+func (m *Manager) stop(started []Component) error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.shutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		component := started[i]
+		if component.Stop == nil {
+			continue
+		}
+
+		if ctx.Err() != nil {
+			m.logger.Error(fmt.Sprintf("%s not stopped, shutdown budget exhausted", component.Name)).
+				Data("timeout", m.shutdownTimeout.String()).
+				Send()
+			errs = append(errs, &SkippedError{Component: component.Name, Timeout: m.shutdownTimeout})
+			continue
+		}
+
+		// done is buffered so the goroutine of a blocked component exits once its hook returns
+		done := make(chan error, 1)
+		go func() {
+			done <- component.Stop(ctx)
+		}()
+
+		var err error
+		select {
+		case err = <-done:
+		case <-ctx.Done():
+			// A hook returning right at the deadline finished in time
+			select {
+			case err = <-done:
+			default:
+				m.logger.Error(fmt.Sprintf("%s blocked shutdown", component.Name)).
+					Data("timeout", m.shutdownTimeout.String()).
+					Send()
+				errs = append(errs, &BlockedError{Component: component.Name, Timeout: m.shutdownTimeout})
+				continue
+			}
+		}
+
+		if err != nil {
+			m.logger.Error(fmt.Sprintf("Failed to stop %s", component.Name)).ErrorData(err).Send()
+			errs = append(errs, fmt.Errorf("stop %s: %w", component.Name, err))
+			continue
+		}
+		m.logger.Info(fmt.Sprintf("%s stopped", component.Name)).Send()
+	}
+
+	if len(errs) == 0 {
+		m.logger.Info("Application shutdown completed").Send()
+	}
+
+	return errors.Join(errs...)
+}
+
+// resolveOrder sorts components so that every component comes after its dependencies
+func (m *Manager) resolveOrder() ([]Component, error) {
+	byName := make(map[string]Component, len(m.components))
+	for _, component := range m.components {
+		if _, exists := byName[component.Name]; exists {
+			return nil, fmt.Errorf("component %s registered twice", component.Name)
+		}
+		byName[component.Name] = component
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int, len(m.components))
+	ordered := make([]Component, 0, len(m.components))
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle: %v", append(path, name))
+		}
+
+		component := byName[name]
+		state[name] = visiting
+		for _, dependency := range component.DependsOn {
+			if _, ok := byName[dependency]; !ok {
+				return fmt.Errorf("component %s depends on unknown component %s", name, dependency)
+			}
+			if err := visit(dependency, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		ordered = append(ordered, component)
+
+		return nil
+	}
+
+	for _, component := range m.components {
+		if err := visit(component.Name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// BlockedError is returned when a component does not stop within the shutdown budget
+type BlockedError struct {
+	Component string
+	Timeout   time.Duration
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("component %s blocked shutdown beyond %s", e.Component, e.Timeout)
+}
+
+// SkippedError is returned for a component whose stop hook never ran because an earlier
+// component used up the shutdown budget
+type SkippedError struct {
+	Component string
+	Timeout   time.Duration
+}
+
+func (e *SkippedError) Error() string {
+	return fmt.Sprintf("component %s not stopped, shutdown budget of %s exhausted", e.Component, e.Timeout)
+}
@@ -0,0 +1,223 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"go.risoftinc.com/gologger"
+	"go.risoftinc.com/xarch/config"
+)
+
+func newTestManager(shutdownTimeout time.Duration) IManager {
+	return NewManager(config.LifecycleConfig{
+		StartTimeout:    time.Second,
+		ShutdownTimeout: shutdownTimeout,
+	}, gologger.NewLoggerWithConfig(gologger.LoggerConfig{
+		OutputMode: "terminal",
+		LogLevel:   "error",
+	}))
+}
+
+// recorder collects start and stop events in the order they happen
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) component(name string, dependsOn ...string) Component {
+	return Component{
+		Name:      name,
+		DependsOn: dependsOn,
+		Start: func(ctx context.Context) error {
+			r.add("start " + name)
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			r.add("stop " + name)
+			return nil
+		},
+	}
+}
+
+func TestManagerDependencyOrder(t *testing.T) {
+	rec := &recorder{}
+	manager := newTestManager(time.Second)
+	manager.Register(
+		rec.component("http", "database", "cache"),
+		rec.component("cache", "database"),
+		rec.component("database"),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := manager.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := []string{
+		"start database", "start cache", "start http",
+		"stop http", "stop cache", "stop database",
+	}
+	if !reflect.DeepEqual(rec.events, want) {
+		t.Errorf("events = %v, want %v", rec.events, want)
+	}
+}
+
+func TestManagerRunFailureStopsEverything(t *testing.T) {
+	rec := &recorder{}
+	serveErr := errors.New("address already in use")
+
+	server := rec.component("server", "database")
+	server.Run = func() error {
+		return serveErr
+	}
+
+	manager := newTestManager(time.Second)
+	manager.Register(rec.component("database"), server)
+
+	err := manager.Run(context.Background())
+	if !errors.Is(err, serveErr) {
+		t.Fatalf("Run() error = %v, want %v", err, serveErr)
+	}
+
+	want := []string{"start database", "start server", "stop server", "stop database"}
+	if !reflect.DeepEqual(rec.events, want) {
+		t.Errorf("events = %v, want %v", rec.events, want)
+	}
+}
+
+func TestManagerStartFailureStopsStarted(t *testing.T) {
+	rec := &recorder{}
+	startErr := errors.New("connection refused")
+
+	broken := rec.component("broken", "database")
+	broken.Start = func(ctx context.Context) error {
+		return startErr
+	}
+
+	manager := newTestManager(time.Second)
+	manager.Register(rec.component("database"), broken, rec.component("server", "broken"))
+
+	err := manager.Run(context.Background())
+	if !errors.Is(err, startErr) {
+		t.Fatalf("Run() error = %v, want %v", err, startErr)
+	}
+
+	want := []string{"start database", "stop database"}
+	if !reflect.DeepEqual(rec.events, want) {
+		t.Errorf("events = %v, want %v", rec.events, want)
+	}
+}
+
+func TestManagerReportsBlockedComponent(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	manager := newTestManager(50 * time.Millisecond)
+	manager.Register(Component{
+		Name: "worker",
+		Stop: func(ctx context.Context) error {
+			<-release
+			return nil
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := manager.Run(ctx)
+
+	var blocked *BlockedError
+	if !errors.As(err, &blocked) {
+		t.Fatalf("Run() error = %v, want BlockedError", err)
+	}
+	if blocked.Component != "worker" {
+		t.Errorf("blocked component = %s, want worker", blocked.Component)
+	}
+}
+
+func TestManagerSkipsComponentsAfterBudget(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	rec := &recorder{}
+	manager := newTestManager(50 * time.Millisecond)
+	manager.Register(
+		rec.component("database"),
+		Component{
+			Name:      "worker",
+			DependsOn: []string{"database"},
+			Stop: func(ctx context.Context) error {
+				<-release
+				return nil
+			},
+		},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := manager.Run(ctx)
+
+	var blocked *BlockedError
+	if !errors.As(err, &blocked) || blocked.Component != "worker" {
+		t.Fatalf("Run() error = %v, want worker blocked", err)
+	}
+	var skipped *SkippedError
+	if !errors.As(err, &skipped) || skipped.Component != "database" {
+		t.Fatalf("Run() error = %v, want database skipped", err)
+	}
+	if want := []string{"start database"}; !reflect.DeepEqual(rec.events, want) {
+		t.Errorf("events = %v, want %v", rec.events, want)
+	}
+}
+
+func TestManagerInvalidDependencies(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []Component
+	}{
+		{
+			name: "unknown dependency",
+			components: []Component{
+				{Name: "server", DependsOn: []string{"database"}},
+			},
+		},
+		{
+			name: "dependency cycle",
+			components: []Component{
+				{Name: "a", DependsOn: []string{"b"}},
+				{Name: "b", DependsOn: []string{"a"}},
+			},
+		},
+		{
+			name: "duplicate name",
+			components: []Component{
+				{Name: "database"},
+				{Name: "database"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := newTestManager(time.Second)
+			manager.Register(tt.components...)
+
+			if err := manager.Run(context.Background()); err == nil {
+				t.Errorf("Run() error = nil, want error")
+			}
+		})
+	}
+}
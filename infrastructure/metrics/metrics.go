@@ -0,0 +1,145 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
+	"go.risoftinc.com/goresponse"
+	"go.risoftinc.com/xarch/config"
+	"gorm.io/gorm"
+)
+
+type (
+	// Metrics owns the Prometheus registry and every collector exported on /metrics.
+	// Transports record requests through the middlewares and interceptors, drivers are registered from main.
+	Metrics struct {
+		registry *prometheus.Registry
+		handler  http.Handler
+		cfg      config.MetricsConfig
+
+		HttpRequests        *prometheus.CounterVec
+		HttpRequestDuration *prometheus.HistogramVec
+		GrpcRequests        *prometheus.CounterVec
+		GrpcRequestDuration *prometheus.HistogramVec
+		DBQueryDuration     *prometheus.HistogramVec
+		ConfigReloads       *prometheus.CounterVec
+	}
+)
+
+func NewMetrics(cfg config.MetricsConfig) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		cfg:      cfg,
+
+		HttpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by route, method and response code.",
+		}, []string{"method", "route", "code"}),
+		HttpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by route and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		GrpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "grpc",
+			Name:      "requests_total",
+			Help:      "gRPC requests by full method and status code.",
+		}, []string{"method", "type", "code"}),
+		GrpcRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "gRPC request latency by full method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "type"}),
+		DBQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "db",
+			Name:      "query_duration_seconds",
+			Help:      "GORM statement latency by operation, table and result.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "table", "result"}),
+		ConfigReloads: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "response_manager",
+			Name:      "reloads_total",
+			Help:      "Response message configuration reloads.",
+		}, []string{"source"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.HttpRequests,
+		m.HttpRequestDuration,
+		m.GrpcRequests,
+		m.GrpcRequestDuration,
+		m.DBQueryDuration,
+		m.ConfigReloads,
+	)
+	m.handler = promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+
+	return m
+}
+
+// Handler serves the registry in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return m.handler
+}
+
+// Enabled reports whether requests are recorded and the endpoint is served
+func (m *Metrics) Enabled() bool {
+	return m != nil && m.cfg.Enabled
+}
+
+// RegisterDatabase exports the connection pool statistics and records the duration of every GORM statement
+func (m *Metrics) RegisterDatabase(name string, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	if err := m.registry.Register(collectors.NewDBStatsCollector(sqlDB, name)); err != nil {
+		return fmt.Errorf("failed to register %s pool metrics: %w", name, err)
+	}
+
+	return db.Use(&gormPlugin{duration: m.DBQueryDuration})
+}
+
+// RegisterRedis exports the connection pool statistics of a Redis client
+func (m *Metrics) RegisterRedis(name string, client *redis.Client) error {
+	if err := m.registry.Register(newRedisCollector(m.cfg.Namespace, name, client)); err != nil {
+		return fmt.Errorf("failed to register %s pool metrics: %w", name, err)
+	}
+	return nil
+}
+
+// RegisterResponseManager counts the reloads of the response message configuration
+func (m *Metrics) RegisterResponseManager(source string, manager *goresponse.AsyncConfigManager) {
+	reloads := m.ConfigReloads.WithLabelValues(source)
+	manager.AddCallback(func(oldConfig, newConfig *goresponse.ResponseConfig) {
+		reloads.Inc()
+	})
+}
+
+// ObserveHttp records a finished HTTP request
+func (m *Metrics) ObserveHttp(method, route string, code int, duration time.Duration) {
+	m.HttpRequests.WithLabelValues(method, route, fmt.Sprint(code)).Inc()
+	m.HttpRequestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// ObserveGrpc records a finished gRPC call, rpcType is "unary" or "stream"
+func (m *Metrics) ObserveGrpc(method, rpcType, code string, duration time.Duration) {
+	m.GrpcRequests.WithLabelValues(method, rpcType, code).Inc()
+	m.GrpcRequestDuration.WithLabelValues(method, rpcType).Observe(duration.Seconds())
+}
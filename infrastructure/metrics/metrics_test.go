@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.risoftinc.com/xarch/config"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type metricsTestUser struct {
+	ID   uint
+	Name string
+}
+
+func TestRegisterDatabase(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+
+	m := NewMetrics(config.MetricsConfig{Enabled: true, Namespace: "test"})
+	if err := m.RegisterDatabase("database", db); err != nil {
+		t.Fatalf("RegisterDatabase() error = %v", err)
+	}
+
+	if err := db.AutoMigrate(&metricsTestUser{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	db.Create(&metricsTestUser{Name: "jane"})
+	db.Where("name = ?", "jane").First(&metricsTestUser{})
+	db.Where("name = ?", "john").First(&metricsTestUser{})
+
+	// Pool statistics and the recorded statements are exposed by the handler,
+	// a missing record is not an error
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(recorder.Body)
+
+	for _, want := range []string{
+		`go_sql_open_connections{db_name="database"}`,
+		`test_db_query_duration_seconds_count{operation="create",result="success",table="metrics_test_users"} 1`,
+		`test_db_query_duration_seconds_count{operation="query",result="success",table="metrics_test_users"} 2`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics output does not contain %s", want)
+		}
+	}
+}
+
+func TestObserveRequests(t *testing.T) {
+	m := NewMetrics(config.MetricsConfig{Enabled: true, Namespace: "test"})
+
+	m.ObserveHttp("GET", "/users/:id", 200, 10*time.Millisecond)
+	m.ObserveHttp("GET", "/users/:id", 404, 5*time.Millisecond)
+	m.ObserveGrpc("/health.HealthService/GetHealthMetric", "unary", "Unavailable", time.Millisecond)
+
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"http 200", testutil.ToFloat64(m.HttpRequests.WithLabelValues("GET", "/users/:id", "200")), 1},
+		{"http 404", testutil.ToFloat64(m.HttpRequests.WithLabelValues("GET", "/users/:id", "404")), 1},
+		{"grpc unavailable", testutil.ToFloat64(m.GrpcRequests.WithLabelValues("/health.HealthService/GetHealthMetric", "unary", "Unavailable")), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("counter = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
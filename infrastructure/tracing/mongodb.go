@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// mongoMonitor creates a client span for every command, the command document is never recorded.
+// Started and finished events are matched by request ID.
+type mongoMonitor struct {
+	tracer trace.Tracer
+	spans  sync.Map // request ID -> trace.Span
+}
+
+func newMongoMonitor(tracer trace.Tracer) *event.CommandMonitor {
+	m := &mongoMonitor{tracer: tracer}
+
+	return &event.CommandMonitor{
+		Started:   m.started,
+		Succeeded: m.succeeded,
+		Failed:    m.failed,
+	}
+}
+
+func (m *mongoMonitor) started(ctx context.Context, evt *event.CommandStartedEvent) {
+	attrs := []attribute.KeyValue{
+		attribute.String("db.system.name", "mongodb"),
+		attribute.String("db.namespace", evt.DatabaseName),
+		attribute.String("db.operation.name", evt.CommandName),
+	}
+	// Most commands name their collection in the first element, e.g. {find: "users"}
+	if element, err := evt.Command.IndexErr(0); err == nil {
+		if collection, ok := element.Value().StringValueOK(); ok {
+			attrs = append(attrs, attribute.String("db.collection.name", collection))
+		}
+	}
+
+	_, span := m.tracer.Start(ctx, "mongodb."+evt.CommandName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	m.spans.Store(evt.RequestID, span)
+}
+
+func (m *mongoMonitor) succeeded(ctx context.Context, evt *event.CommandSucceededEvent) {
+	if span, ok := m.finish(evt.RequestID); ok {
+		span.End()
+	}
+}
+
+func (m *mongoMonitor) failed(ctx context.Context, evt *event.CommandFailedEvent) {
+	if span, ok := m.finish(evt.RequestID); ok {
+		span.RecordError(errors.New(evt.Failure))
+		span.SetStatus(codes.Error, evt.Failure)
+		span.End()
+	}
+}
+
+func (m *mongoMonitor) finish(requestID int64) (trace.Span, bool) {
+	value, ok := m.spans.LoadAndDelete(requestID)
+	if !ok {
+		return nil, false
+	}
+	span, ok := value.(trace.Span)
+	return span, ok
+}
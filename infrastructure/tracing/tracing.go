@@ -0,0 +1,141 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.risoftinc.com/xarch/config"
+	"gorm.io/gorm"
+)
+
+// TracerName identifies the spans created by this application
+const TracerName = "go.risoftinc.com/xarch"
+
+type (
+	// Tracing owns the tracer provider and the W3C trace-context propagator.
+	// Transports start server spans through the middlewares and interceptors, drivers are registered from main.
+	Tracing struct {
+		provider   trace.TracerProvider
+		tracer     trace.Tracer
+		propagator propagation.TextMapPropagator
+		shutdown   func(ctx context.Context) error
+		enabled    bool
+	}
+)
+
+// NewTracing builds the exporter selected by TRACING_EXPORTER and installs the provider and
+// propagator globally. With "none" spans are not recorded but incoming trace context is still honoured.
+func NewTracing(cfg config.TracingConfig) (*Tracing, error) {
+	var exporter sdktrace.SpanExporter
+
+	switch cfg.Exporter {
+	case "none":
+		return newTracing(noop.NewTracerProvider(), nil, false), nil
+	case "stdout":
+		stdout, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout trace exporter: %w", err)
+		}
+		exporter = stdout
+	case "otlp":
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		otlp, err := otlptracegrpc.New(context.Background(), opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+		}
+		exporter = otlp
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter %q", cfg.Exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.ServiceName))),
+	)
+
+	return newTracing(provider, provider.Shutdown, true), nil
+}
+
+// NewTracingWithProvider uses an existing provider, e.g. one backed by an in-memory exporter in tests
+func NewTracingWithProvider(provider trace.TracerProvider) *Tracing {
+	return newTracing(provider, nil, true)
+}
+
+func newTracing(provider trace.TracerProvider, shutdown func(ctx context.Context) error, enabled bool) *Tracing {
+	propagator := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+
+	return &Tracing{
+		provider:   provider,
+		tracer:     provider.Tracer(TracerName),
+		propagator: propagator,
+		shutdown:   shutdown,
+		enabled:    enabled,
+	}
+}
+
+// Tracer starts the spans of this application
+func (t *Tracing) Tracer() trace.Tracer {
+	return t.tracer
+}
+
+// Propagator extracts and injects the traceparent, tracestate and baggage headers
+func (t *Tracing) Propagator() propagation.TextMapPropagator {
+	return t.propagator
+}
+
+// Enabled reports whether spans are recorded and exported
+func (t *Tracing) Enabled() bool {
+	return t != nil && t.enabled
+}
+
+// Shutdown flushes the pending spans and stops the exporter
+func (t *Tracing) Shutdown(ctx context.Context) error {
+	if t == nil || t.shutdown == nil {
+		return nil
+	}
+	return t.shutdown(ctx)
+}
+
+// RegisterDatabase creates a span for every GORM statement
+func (t *Tracing) RegisterDatabase(db *gorm.DB) error {
+	return db.Use(&gormPlugin{tracer: t.tracer, system: db.Dialector.Name()})
+}
+
+// RegisterRedis creates a span for every Redis command and pipeline
+func (t *Tracing) RegisterRedis(client *redis.Client) {
+	client.AddHook(&redisHook{tracer: t.tracer})
+}
+
+// MongoOptions returns the client options that create a span for every MongoDB command,
+// pass them to driver.ConnectMongoDB
+func (t *Tracing) MongoOptions() *options.ClientOptions {
+	return options.Client().SetMonitor(newMongoMonitor(t.tracer))
+}
+
+// IDs returns the trace and span IDs of the span in ctx, both are empty without a valid span
+func IDs(ctx context.Context) (traceID, spanID string) {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return "", ""
+	}
+	return spanContext.TraceID().String(), spanContext.SpanID().String()
+}
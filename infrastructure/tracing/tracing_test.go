@@ -0,0 +1,161 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type tracingTestUser struct {
+	ID   uint
+	Name string
+}
+
+func newTestTracing(t *testing.T) (*Tracing, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	return NewTracingWithProvider(provider), exporter
+}
+
+func attributeOf(span tracetest.SpanStub, key string) attribute.Value {
+	for _, attr := range span.Attributes {
+		if string(attr.Key) == key {
+			return attr.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestRegisterDatabase(t *testing.T) {
+	tracing, exporter := newTestTracing(t)
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(&tracingTestUser{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	if err := tracing.RegisterDatabase(db); err != nil {
+		t.Fatalf("RegisterDatabase() error = %v", err)
+	}
+
+	ctx, parent := tracing.Tracer().Start(context.Background(), "handler")
+	db.WithContext(ctx).Create(&tracingTestUser{Name: "jane"})
+	db.WithContext(ctx).Where("name = ?", "john").First(&tracingTestUser{})
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 3", len(spans))
+	}
+
+	for i, want := range []string{"gorm.create", "gorm.query"} {
+		span := spans[i]
+		if span.Name != want {
+			t.Errorf("span %d name = %q, want %q", i, span.Name, want)
+		}
+		if span.Parent.SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("span %q is not a child of the handler span", span.Name)
+		}
+		if table := attributeOf(span, "db.collection.name").AsString(); table != "tracing_test_users" {
+			t.Errorf("span %q table = %q", span.Name, table)
+		}
+		// A missing record is not an error
+		if span.Status.Code == codes.Error {
+			t.Errorf("span %q status = %v", span.Name, span.Status)
+		}
+	}
+	if query := attributeOf(spans[1], "db.query.text").AsString(); query == "" || strings.Contains(query, "john") {
+		t.Errorf("query text = %q, want the statement without bound values", query)
+	}
+}
+
+func TestRedisHook(t *testing.T) {
+	tracing, exporter := newTestTracing(t)
+	hook := &redisHook{tracer: tracing.Tracer()}
+
+	tests := []struct {
+		name      string
+		err       error
+		wantError bool
+	}{
+		{name: "hit"},
+		{name: "missing key", err: redis.Nil},
+		{name: "connection refused", err: errors.New("connection refused"), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter.Reset()
+
+			process := hook.ProcessHook(func(ctx context.Context, cmd redis.Cmder) error {
+				return tt.err
+			})
+			ctx := context.Background()
+			_ = process(ctx, redis.NewStringCmd(ctx, "get", "session:1"))
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 || spans[0].Name != "redis.get" {
+				t.Fatalf("spans = %v, want one redis.get span", spans)
+			}
+			if got := spans[0].Status.Code == codes.Error; got != tt.wantError {
+				t.Errorf("error status = %v, want %v", got, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestMongoMonitor(t *testing.T) {
+	tracing, exporter := newTestTracing(t)
+	monitor := newMongoMonitor(tracing.Tracer())
+
+	command, _ := bson.Marshal(bson.D{{Key: "find", Value: "users"}})
+	ctx := context.Background()
+
+	monitor.Started(ctx, &event.CommandStartedEvent{Command: command, DatabaseName: "app", CommandName: "find", RequestID: 1})
+	monitor.Started(ctx, &event.CommandStartedEvent{Command: command, DatabaseName: "app", CommandName: "find", RequestID: 2})
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{RequestID: 1}})
+	monitor.Failed(ctx, &event.CommandFailedEvent{CommandFinishedEvent: event.CommandFinishedEvent{RequestID: 2}, Failure: "timeout"})
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	if collection := attributeOf(spans[0], "db.collection.name").AsString(); collection != "users" {
+		t.Errorf("collection = %q, want users", collection)
+	}
+	if spans[0].Status.Code == codes.Error || spans[1].Status.Code != codes.Error {
+		t.Errorf("statuses = %v, %v, want only the failed command in error", spans[0].Status, spans[1].Status)
+	}
+}
+
+func TestIDs(t *testing.T) {
+	tracing, _ := newTestTracing(t)
+
+	if traceID, spanID := IDs(context.Background()); traceID != "" || spanID != "" {
+		t.Errorf("IDs() without span = (%q, %q), want empty", traceID, spanID)
+	}
+
+	ctx, span := tracing.Tracer().Start(context.Background(), "request")
+	defer span.End()
+
+	traceID, spanID := IDs(ctx)
+	if traceID != span.SpanContext().TraceID().String() || spanID != span.SpanContext().SpanID().String() {
+		t.Errorf("IDs() = (%q, %q), want the IDs of the active span", traceID, spanID)
+	}
+}
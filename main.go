@@ -1,12 +1,21 @@
 package main
 
 import (
+	"context"
 	"log"
-	"sync"
+	"os"
+	"time"
 
+	"github.com/redis/go-redis/v9"
 	"go.risoftinc.com/gologger"
 	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/constant"
 	"go.risoftinc.com/xarch/driver"
+	"go.risoftinc.com/xarch/infrastructure/lifecycle"
+	"go.risoftinc.com/xarch/infrastructure/metrics"
+	"go.risoftinc.com/xarch/infrastructure/tracing"
+	"go.risoftinc.com/xarch/utils/healthcheck"
+	"go.risoftinc.com/xarch/utils/token"
 
 	grpc "go.risoftinc.com/xarch/infrastructure/grpc/engine"
 	http "go.risoftinc.com/xarch/infrastructure/http/engine"
@@ -16,23 +25,162 @@ func main() {
 	// Load configuration
 	cfg := config.Configuration()
 
-	// Connect to database using existing driver
-	db := driver.ConnectDB(cfg.Database)
-	defer driver.CloseDB(db)
+	// `migrate <command>` runs the embedded migrations and exits without starting the servers
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(cfg, os.Args[2:]))
+	}
 
-	// MongoDB Connection Example (uncomment to use)
-	// mongoDB := driver.ConnectMongoDB(cfg.MongoDB)
-	// if mongoDB != nil {
-	// 	defer driver.CloseMongoDB(mongoDB.Client())
-	// 	log.Println("MongoDB connected successfully")
-	// }
+	// Initialize logger with config
+	logger := gologger.NewLoggerWithConfig(gologger.LoggerConfig{
+		OutputMode:   cfg.Logger.OutputMode,
+		LogLevel:     cfg.Logger.LogLevel,
+		LogDir:       cfg.Logger.LogDir,
+		RequestIDKey: "traceID",
+		ShowCaller:   true,
+	})
+
+	// Lifecycle manager owns the signal handling and the shutdown order of every component
+	manager := lifecycle.NewManager(cfg.Lifecycle, logger)
+
+	// Health registry collects a checker for every dependency, critical failures make the application down
+	healthRegistry := healthcheck.NewRegistry(cfg.Health)
+	probes := healthcheck.NewProbes(healthRegistry)
+
+	// Prometheus metrics served on /metrics, drivers register their pool statistics below
+	appMetrics := metrics.NewMetrics(cfg.Metrics)
+
+	// OpenTelemetry tracing, registered first so it stops last and flushes the spans of every other component
+	appTracing, err := tracing.NewTracing(cfg.Tracing)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	manager.Register(lifecycle.Component{
+		Name: constant.ComponentTracing,
+		Stop: appTracing.Shutdown,
+	})
+
+	// JWT verifier shared by the HTTP auth middleware and the gRPC auth interceptors, it also signs issued tokens
+	verifier, err := token.NewVerifier(token.Options{
+		Secret:         cfg.Auth.Secret,
+		JWKSFile:       cfg.Auth.JWKSFile,
+		ReloadInterval: cfg.Auth.JWKSReloadInterval,
+		Issuer:         cfg.Auth.Issuer,
+		Audience:       cfg.Auth.Audience,
+		ClockSkew:      cfg.Auth.ClockSkew,
+		SigningKeyID:   cfg.Auth.SigningKeyID,
+		OnReload: func(err error) {
+			if err != nil {
+				logger.Error("Failed to reload JWKS file, keeping the previous keys").ErrorData(err).Send()
+				return
+			}
+			logger.Info("JWKS file reloaded").Send()
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+	if cfg.Auth.Secret == "" && cfg.Auth.JWKSFile == "" {
+		logger.Warn("JWT_SECRET and JWT_JWKS_FILE are empty, authenticated endpoints reject every request").Send()
+	}
+
+	// Connect to database using existing driver, retried with CONNECT_*. With CONNECT_START_DEGRADED the servers
+	// start right away and readiness fails until the background connection succeeds. Statements are logged
+	// through the application logger with the request ID.
+	db, dbConn, err := driver.ConnectDB(context.Background(), cfg.Database, cfg.Connect,
+		driver.WithLogger(driver.NewGormLogger(logger, cfg.Database)))
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	// With MIGRATE_ON_STARTUP every replica runs the pending migrations, the migration lock lets one of them
+	// apply them while the others wait, so no instance serves before the schema is up to date
+	if cfg.Migrate.OnStartup {
+		count, err := newMigrator(db, cfg).Up(context.Background())
+		if err != nil {
+			log.Fatalf("Failed to migrate database: %v", err)
+		}
+		logger.Info("Database migrated").Data("applied", count).Send()
+	}
+	manager.Register(lifecycle.Component{
+		Name: constant.ComponentDatabase,
+		Stop: func(ctx context.Context) error {
+			dbConn.Stop()
+			driver.CloseDB(db)
+			return nil
+		},
+	})
+	healthRegistry.Register(healthcheck.Checker{
+		Name:     constant.ComponentDatabase,
+		Critical: true,
+		Check:    dbConn.HealthCheck(driver.DatabaseHealthCheck(db)),
+	})
+	for _, replica := range driver.Replicas(db) {
+		// A replica outage only degrades the application, writes and primary reads keep working
+		healthRegistry.Register(healthcheck.Checker{
+			Name:  constant.ComponentDatabase + "-" + replica.Name,
+			Check: driver.ReplicaHealthCheck(replica),
+		})
+	}
+	if appMetrics.Enabled() {
+		if err := appMetrics.RegisterDatabase(constant.ComponentDatabase, db); err != nil {
+			log.Fatalf("Failed to register database metrics: %v", err)
+		}
+	}
+	if appTracing.Enabled() {
+		if err := appTracing.RegisterDatabase(db); err != nil {
+			log.Fatalf("Failed to register database tracing: %v", err)
+		}
+	}
 
-	// Redis Connection Example (uncomment to use)
-	// redisClient := driver.ConnectRedis(cfg.Redis)
-	// if redisClient != nil {
-	// 	defer driver.CloseRedis(redisClient)
-	// 	log.Println("Redis connected successfully")
+	// MongoDB Connection Example (uncomment to use with MONGODB_ENABLED=true)
+	// mongoDB, mongoConn, err := driver.ConnectMongoDB(context.Background(), cfg.MongoDB, cfg.Connect, appTracing.MongoOptions())
+	// if err != nil {
+	// 	log.Fatalf("Failed to connect to MongoDB: %v", err)
 	// }
+	// manager.Register(lifecycle.Component{
+	// 	Name: constant.ComponentMongoDB,
+	// 	Stop: func(ctx context.Context) error {
+	// 		mongoConn.Stop()
+	// 		driver.CloseMongoDB(mongoDB.Client())
+	// 		return nil
+	// 	},
+	// })
+	// healthRegistry.Register(healthcheck.Checker{
+	// 	Name:     constant.ComponentMongoDB,
+	// 	Critical: true,
+	// 	Check:    mongoConn.HealthCheck(driver.MongoDBHealthCheck(mongoDB)),
+	// })
+
+	// Redis is connected with REDIS_ENABLED and stores the access-token denylist with AUTH_DENYLIST_STORE=redis,
+	// the application does not start without it
+	var redisClient *redis.Client
+	if cfg.Redis.Enabled {
+		var redisConn *driver.Connection
+		redisClient, redisConn, err = driver.ConnectRedis(context.Background(), cfg.Redis, cfg.Connect)
+		if err != nil {
+			log.Fatalf("Failed to connect to Redis: %v", err)
+		}
+		manager.Register(lifecycle.Component{
+			Name: constant.ComponentRedis,
+			Stop: func(ctx context.Context) error {
+				redisConn.Stop()
+				driver.CloseRedis(redisClient)
+				return nil
+			},
+		})
+		healthRegistry.Register(healthcheck.Checker{
+			Name:     constant.ComponentRedis,
+			Critical: true,
+			Check:    redisConn.HealthCheck(driver.RedisHealthCheck(redisClient)),
+		})
+		if appMetrics.Enabled() {
+			if err := appMetrics.RegisterRedis(constant.ComponentRedis, redisClient); err != nil {
+				log.Fatalf("Failed to register Redis metrics: %v", err)
+			}
+		}
+		if appTracing.Enabled() {
+			appTracing.RegisterRedis(redisClient)
+		}
+	}
 
 	// Load response manager
 
@@ -46,37 +194,79 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load response manager: %v", err)
 	}
-	defer responseManager.Stop()
-
-	// Initialize logger with config
-	logger := gologger.NewLoggerWithConfig(gologger.LoggerConfig{
-		OutputMode:   cfg.Logger.OutputMode,
-		LogLevel:     cfg.Logger.LogLevel,
-		LogDir:       cfg.Logger.LogDir,
-		RequestIDKey: "traceID",
-		ShowCaller:   true,
+	manager.Register(lifecycle.Component{
+		Name: constant.ComponentResponseManager,
+		Stop: func(ctx context.Context) error {
+			responseManager.Stop()
+			return nil
+		},
 	})
-	defer logger.Close()
-
-	// Simple approach - just start both servers and wait for signal
-	var wg sync.WaitGroup
+	healthRegistry.Register(healthcheck.Checker{
+		Name:  constant.ComponentResponseManager,
+		Check: driver.ResponseManagerHealthCheck(responseManager),
+	})
+	if appMetrics.Enabled() {
+		appMetrics.RegisterResponseManager(cfg.ResponseManager.Method, responseManager)
+	}
 
-	// Start HTTP server
-	http.Start(http.App{
+	// Register HTTP server
+	manager.Register(http.Component(http.App{
 		Config:          cfg,
 		Logger:          logger,
 		DB:              db,
 		ResponseManager: responseManager,
-	}, &wg)
+		HealthRegistry:  healthRegistry,
+		Probes:          probes,
+		Metrics:         appMetrics,
+		Tracing:         appTracing,
+		Verifier:        verifier,
+		Signer:          verifier,
+		Redis:           redisClient,
+	}))
 
-	// Start GRPC server
-	grpc.StartGRPC(grpc.App{
+	// Register GRPC server
+	manager.Register(grpc.Component(grpc.App{
 		Config:          cfg,
 		Logger:          logger,
 		DB:              db,
 		ResponseManager: responseManager,
-	}, &wg)
+		HealthRegistry:  healthRegistry,
+		Probes:          probes,
+		Metrics:         appMetrics,
+		Tracing:         appTracing,
+		Verifier:        verifier,
+		Signer:          verifier,
+		Redis:           redisClient,
+	}))
+
+	// Probes depend on both servers: they start last, so startup completes once everything is serving,
+	// and stop first, so readiness fails and load balancers drain before the servers shut down
+	manager.Register(lifecycle.Component{
+		Name:      constant.ComponentProbes,
+		DependsOn: []string{constant.ComponentHttpServer, constant.ComponentGrpcServer},
+		Start: func(ctx context.Context) error {
+			probes.MarkStarted()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			probes.MarkDraining()
+			logger.Info("Readiness disabled, draining connections").Data("delay", cfg.Lifecycle.DrainDelay.String()).Send()
+
+			select {
+			case <-time.After(cfg.Lifecycle.DrainDelay):
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+
+	// Start every component and wait for a shutdown signal or a component failure
+	if err := manager.Run(context.Background()); err != nil {
+		logger.Error("Application stopped with error").ErrorData(err).Send()
+		logger.Close()
+		os.Exit(1)
+	}
 
-	// Wait for both servers to complete
-	wg.Wait()
+	logger.Close()
 }
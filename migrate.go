@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go.risoftinc.com/xarch/config"
+	"go.risoftinc.com/xarch/database/migration"
+	"go.risoftinc.com/xarch/driver"
+	"go.risoftinc.com/xarch/utils/migrate"
+	"gorm.io/gorm"
+)
+
+const (
+	migrateUsage = "go run . migrate"
+	// migrationDir is the source folder of the embedded migrations, relative to the repository root
+	migrationDir = "database/migration"
+)
+
+// newMigrator runs the embedded migrations of DB_TYPE with MIGRATE_*
+func newMigrator(db *gorm.DB, cfg config.Config) *migrate.Migrator {
+	return migrate.NewMigrator(db, migration.Files, migrate.Options{
+		Dialect:     cfg.Database.Type,
+		Sets:        cfg.Migrate.Sets,
+		Table:       cfg.Migrate.Table,
+		LockTimeout: cfg.Migrate.LockTimeout,
+	})
+}
+
+// runMigrate handles `migrate [-sets=ddl,dml] <command>` and returns the exit code.
+// It connects to the primary only and waits for it, even with CONNECT_START_DEGRADED.
+func runMigrate(cfg config.Config, args []string) int {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	sets := flags.String("sets", strings.Join(cfg.Migrate.Sets, ","), "comma-separated migration sets, overrides MIGRATE_SETS")
+	flags.Usage = func() { migrateHelp(flags) }
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	cfg.Migrate.Sets = strings.Split(*sets, ",")
+	if err := cfg.Migrate.Validate(); err != nil {
+		log.Printf("Invalid migration options: %v", err)
+		return 2
+	}
+
+	command, steps, version := flags.Arg(0), 1, flags.Arg(1)
+	switch {
+	case command == "create":
+		return createMigration(cfg, flags.Arg(1), strings.Join(flags.Args()[min(2, flags.NArg()):], " "))
+	case command == "down" && version != "":
+		n, err := strconv.Atoi(version)
+		if err != nil || n < 1 {
+			log.Printf("down expects a positive number of steps, got %q", version)
+			return 2
+		}
+		steps = n
+	case command == "goto" && version == "":
+		log.Print("goto expects a version, use 0 to revert every migration")
+		return 2
+	case command != "up" && command != "down" && command != "goto" && command != "status":
+		migrateHelp(flags)
+		return 2
+	}
+
+	cfg.Database.Replicas = nil
+	cfg.Connect.StartDegraded = false
+	ctx := context.Background()
+	db, _, err := driver.ConnectDB(ctx, cfg.Database, cfg.Connect)
+	if err != nil {
+		log.Printf("Failed to connect to database: %v", err)
+		return 1
+	}
+	defer driver.CloseDB(db)
+
+	migrator := newMigrator(db, cfg)
+	var count int
+	switch command {
+	case "up":
+		count, err = migrator.Up(ctx)
+	case "down":
+		count, err = migrator.Down(ctx, steps)
+	case "goto":
+		count, err = migrator.Goto(ctx, version)
+	case "status":
+		err = printMigrations(ctx, migrator)
+	}
+	if err != nil {
+		log.Printf("Migration %s failed after %d migrations: %v", command, count, err)
+		return 1
+	}
+	if command != "status" {
+		fmt.Printf("Migration %s finished, %d migrations run\n", command, count)
+	}
+	return 0
+}
+
+// createMigration writes the files of a new migration of DB_TYPE to the source tree, they are embedded on the next build
+func createMigration(cfg config.Config, set, name string) int {
+	cfg.Migrate.Sets = []string{set}
+	if err := cfg.Migrate.Validate(); err != nil {
+		log.Printf("create expects a set and a name: %v", err)
+		return 2
+	}
+
+	paths, err := migrate.Create(migrationDir, cfg.Database.Type, set, name, time.Now())
+	if err != nil {
+		log.Printf("Failed to create migration: %v", err)
+		return 1
+	}
+	for _, path := range paths {
+		fmt.Println("Created", path)
+	}
+	return 0
+}
+
+// printMigrations prints the state of every migration of the files and of the version table
+func printMigrations(ctx context.Context, migrator migrate.IMigrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tSET\tNAME\tSTATE\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "-"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Local().Format(time.DateTime)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", status.Version, status.Set, status.Name, status.State, appliedAt)
+	}
+	return w.Flush()
+}
+
+func migrateHelp(flags *flag.FlagSet) {
+	fmt.Fprintf(os.Stderr, "Usage: %s [-sets=ddl,dml] <command>\n\n", migrateUsage)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	fmt.Fprintln(os.Stderr, "  up                   apply every pending migration")
+	fmt.Fprintln(os.Stderr, "  down [n]             revert the last n applied migrations, 1 by default")
+	fmt.Fprintln(os.Stderr, "  goto <version>       apply or revert until version is the last applied, 0 reverts all")
+	fmt.Fprintln(os.Stderr, "  status               print the state of every migration")
+	fmt.Fprintln(os.Stderr, "  create <set> <name>  write empty up and down files to database/migration/<DB_TYPE>/<set>")
+	fmt.Fprintln(os.Stderr, "\nFlags:")
+	flags.PrintDefaults()
+}
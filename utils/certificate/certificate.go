@@ -0,0 +1,268 @@
+package certificate
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Client authentication modes
+const (
+	ClientAuthNone             = "none"
+	ClientAuthRequest          = "request"
+	ClientAuthRequire          = "require"
+	ClientAuthVerifyIfGiven    = "verify-if-given"
+	ClientAuthRequireAndVerify = "require-and-verify"
+)
+
+type (
+	// Options describes the certificate files and TLS settings of a server
+	Options struct {
+		CertFile     string
+		KeyFile      string
+		ClientCAFile string
+		ClientAuth   string
+		MinVersion   string
+		// ReloadInterval is the minimum time between two checks of the files on disk,
+		// zero disables reloading
+		ReloadInterval time.Duration
+		// OnReload is called after every reload attempt with its result
+		OnReload func(err error)
+	}
+
+	// Reloader serves the certificate and client CA pool from disk and reloads them when the
+	// files change, so short-lived certificates can be rotated without a restart
+	Reloader struct {
+		opts       Options
+		clientAuth tls.ClientAuthType
+		minVersion uint16
+
+		mu        sync.RWMutex
+		cert      *tls.Certificate
+		clientCAs *x509.CertPool
+		modTimes  map[string]time.Time
+		lastCheck time.Time
+	}
+)
+
+// NewReloader loads the certificate files once and fails when they are missing or invalid
+func NewReloader(opts Options) (*Reloader, error) {
+	clientAuth, err := ParseClientAuth(opts.ClientAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	minVersion, err := ParseMinVersion(opts.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if clientAuth >= tls.VerifyClientCertIfGiven && opts.ClientCAFile == "" {
+		return nil, errors.New("client certificate verification requires a client CA file")
+	}
+
+	r := &Reloader{
+		opts:       opts,
+		clientAuth: clientAuth,
+		minVersion: minVersion,
+	}
+
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// TLSConfig returns a server TLS configuration that always uses the latest certificate and client CAs
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: r.minVersion,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.maybeReload()
+
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			return &tls.Config{
+				MinVersion:   r.minVersion,
+				Certificates: []tls.Certificate{*r.cert},
+				ClientAuth:   r.clientAuth,
+				ClientCAs:    r.clientCAs,
+				NextProtos:   []string{"h2", "http/1.1"},
+			}, nil
+		},
+	}
+}
+
+// Certificate returns the certificate currently served
+func (r *Reloader) Certificate() *tls.Certificate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert
+}
+
+// maybeReload reloads the files when the reload interval has passed and any file changed.
+// A failed reload keeps serving the previous certificate.
+func (r *Reloader) maybeReload() {
+	if r.opts.ReloadInterval <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	if time.Since(r.lastCheck) < r.opts.ReloadInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.lastCheck = time.Now()
+	changed := r.changed()
+	r.mu.Unlock()
+
+	if !changed {
+		return
+	}
+
+	err := r.reload()
+	if r.opts.OnReload != nil {
+		r.opts.OnReload(err)
+	}
+}
+
+// changed reports whether any watched file has a different modification time, callers hold the lock
+func (r *Reloader) changed() bool {
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return true
+		}
+		if !info.ModTime().Equal(r.modTimes[file]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.opts.CertFile, r.opts.KeyFile}
+	if r.opts.ClientCAFile != "" {
+		files = append(files, r.opts.ClientCAFile)
+	}
+	return files
+}
+
+// reload reads every file and swaps the served certificate and CA pool
+func (r *Reloader) reload() error {
+	modTimes := make(map[string]time.Time)
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		modTimes[file] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.opts.CertFile, r.opts.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.opts.ClientCAFile != "" {
+		clientCAs, err = LoadCertPool(r.opts.ClientCAFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	r.lastCheck = time.Now()
+
+	return nil
+}
+
+// LoadCertPool reads a PEM bundle of CA certificates
+func LoadCertPool(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", file)
+	}
+
+	return pool, nil
+}
+
+// ParseClientAuth converts a client authentication mode name to its TLS value
+func ParseClientAuth(mode string) (tls.ClientAuthType, error) {
+	switch mode {
+	case "", ClientAuthNone:
+		return tls.NoClientCert, nil
+	case ClientAuthRequest:
+		return tls.RequestClientCert, nil
+	case ClientAuthRequire:
+		return tls.RequireAnyClientCert, nil
+	case ClientAuthVerifyIfGiven:
+		return tls.VerifyClientCertIfGiven, nil
+	case ClientAuthRequireAndVerify:
+		return tls.RequireAndVerifyClientCert, nil
+	default:
+		return tls.NoClientCert, fmt.Errorf("unsupported client auth mode %q", mode)
+	}
+}
+
+// ParseMinVersion converts a TLS version such as "1.2" to its TLS value, defaulting to TLS 1.2
+func ParseMinVersion(version string) (uint16, error) {
+	switch version {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported minimum TLS version %q", version)
+	}
+}
+
+// Identity is the verified identity presented by a client certificate
+type Identity struct {
+	Subject        string   `json:"subject"`
+	CommonName     string   `json:"common_name"`
+	DNSNames       []string `json:"dns_names,omitempty"`
+	EmailAddresses []string `json:"email_addresses,omitempty"`
+	IPAddresses    []string `json:"ip_addresses,omitempty"`
+	URIs           []string `json:"uris,omitempty"`
+}
+
+// IdentityFromState returns the identity of the verified client certificate of a connection.
+// Unverified certificates are ignored, so the identity is only present when the chain was checked against the client CA.
+func IdentityFromState(state tls.ConnectionState) (Identity, bool) {
+	if len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return Identity{}, false
+	}
+
+	cert := state.VerifiedChains[0][0]
+	identity := Identity{
+		Subject:        cert.Subject.String(),
+		CommonName:     cert.Subject.CommonName,
+		DNSNames:       cert.DNSNames,
+		EmailAddresses: cert.EmailAddresses,
+	}
+	for _, ip := range cert.IPAddresses {
+		identity.IPAddresses = append(identity.IPAddresses, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		identity.URIs = append(identity.URIs, uri.String())
+	}
+
+	return identity, true
+}
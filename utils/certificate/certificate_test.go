@@ -0,0 +1,390 @@
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCA signs certificates generated by the tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate CA key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "xarch test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create CA certificate: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+
+	return &testCA{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// issue creates a certificate signed by the CA and returns it PEM encoded with its key
+func (ca *testCA) issue(t *testing.T, serial int64, commonName string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, path string, content []byte, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("failed to touch %s: %v", path, err)
+	}
+}
+
+// serve accepts TLS connections with the given config and completes their handshake
+func serve(t *testing.T, config *tls.Config) string {
+	t.Helper()
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+// peerSerial connects to the server and returns the serial number of the served certificate
+func peerSerial(t *testing.T, address string, config *tls.Config) (int64, error) {
+	t.Helper()
+
+	conn, err := tls.Dial("tcp", address, config)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	if err := conn.Handshake(); err != nil {
+		return 0, err
+	}
+	// Force a read so that a rejected client certificate is reported on TLS 1.3
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	buf := make([]byte, 1)
+	if _, err := conn.Read(buf); err != nil && !errors.Is(err, io.EOF) {
+		return 0, err
+	}
+
+	return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64(), nil
+}
+
+func TestReloaderRotatesCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+
+	cert, key := ca.issue(t, 100, "localhost", x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, cert, time.Now().Add(-time.Minute))
+	writeFile(t, keyFile, key, time.Now().Add(-time.Minute))
+
+	reloaded := make(chan error, 1)
+	reloader, err := NewReloader(Options{
+		CertFile:       certFile,
+		KeyFile:        keyFile,
+		ReloadInterval: time.Millisecond,
+		OnReload: func(err error) {
+			reloaded <- err
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(ca.pem)
+	clientConfig := &tls.Config{RootCAs: roots, ServerName: "localhost"}
+	address := serve(t, reloader.TLSConfig())
+
+	serial, err := peerSerial(t, address, clientConfig)
+	if err != nil {
+		t.Fatalf("handshake error = %v", err)
+	}
+	if serial != 100 {
+		t.Fatalf("served serial = %d, want 100", serial)
+	}
+
+	// Rotate the certificate on disk
+	cert, key = ca.issue(t, 200, "localhost", x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, cert, time.Now())
+	writeFile(t, keyFile, key, time.Now())
+	time.Sleep(5 * time.Millisecond)
+
+	// The first handshake after the interval notices the change and reloads
+	peerSerial(t, address, clientConfig)
+	if err := <-reloaded; err != nil {
+		t.Fatalf("reload error = %v", err)
+	}
+
+	serial, err = peerSerial(t, address, clientConfig)
+	if err != nil {
+		t.Fatalf("handshake error = %v", err)
+	}
+	if serial != 200 {
+		t.Errorf("served serial after rotation = %d, want 200", serial)
+	}
+}
+
+func TestReloaderKeepsCertificateOnInvalidFile(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+
+	cert, key := ca.issue(t, 100, "localhost", x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, cert, time.Now().Add(-time.Minute))
+	writeFile(t, keyFile, key, time.Now().Add(-time.Minute))
+
+	reloaded := make(chan error, 1)
+	reloader, err := NewReloader(Options{
+		CertFile:       certFile,
+		KeyFile:        keyFile,
+		ReloadInterval: time.Millisecond,
+		OnReload: func(err error) {
+			reloaded <- err
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+
+	writeFile(t, certFile, []byte("not a certificate"), time.Now())
+	time.Sleep(5 * time.Millisecond)
+	reloader.maybeReload()
+
+	if err := <-reloaded; err == nil {
+		t.Fatal("reload error = nil, want error for invalid certificate")
+	}
+	if reloader.Certificate() == nil {
+		t.Error("Certificate() = nil, want previous certificate")
+	}
+}
+
+func TestReloaderClientCertificateVerification(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	caFile := filepath.Join(dir, "ca.crt")
+
+	cert, key := ca.issue(t, 100, "localhost", x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, cert, time.Now())
+	writeFile(t, keyFile, key, time.Now())
+	writeFile(t, caFile, ca.pem, time.Now())
+
+	reloader, err := NewReloader(Options{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		ClientCAFile: caFile,
+		ClientAuth:   ClientAuthRequireAndVerify,
+		MinVersion:   "1.3",
+	})
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(ca.pem)
+	address := serve(t, reloader.TLSConfig())
+
+	clientPEM, clientKey := ca.issue(t, 300, "billing-service", x509.ExtKeyUsageClientAuth)
+	clientCert, err := tls.X509KeyPair(clientPEM, clientKey)
+	if err != nil {
+		t.Fatalf("failed to load client certificate: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		config  *tls.Config
+		wantErr bool
+	}{
+		{
+			name:    "without client certificate",
+			config:  &tls.Config{RootCAs: roots, ServerName: "localhost"},
+			wantErr: true,
+		},
+		{
+			name:    "with client certificate",
+			config:  &tls.Config{RootCAs: roots, ServerName: "localhost", Certificates: []tls.Certificate{clientCert}},
+			wantErr: false,
+		},
+		{
+			name:    "below minimum version",
+			config:  &tls.Config{RootCAs: roots, ServerName: "localhost", MaxVersion: tls.VersionTLS12},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := peerSerial(t, address, tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("handshake error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewReloaderInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{
+			name: "missing files",
+			opts: Options{CertFile: "missing.crt", KeyFile: "missing.key"},
+		},
+		{
+			name: "unknown client auth",
+			opts: Options{ClientAuth: "sometimes"},
+		},
+		{
+			name: "unknown minimum version",
+			opts: Options{MinVersion: "1.0"},
+		},
+		{
+			name: "verification without CA",
+			opts: Options{ClientAuth: ClientAuthRequireAndVerify},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewReloader(tt.opts); err == nil {
+				t.Error("NewReloader() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestIdentityFromState(t *testing.T) {
+	ca := newTestCA(t)
+	certPEM, _ := ca.issue(t, 300, "billing-service", x509.ExtKeyUsageClientAuth)
+	block, _ := pem.Decode(certPEM)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		state  tls.ConnectionState
+		wantOK bool
+	}{
+		{
+			name:   "no client certificate",
+			state:  tls.ConnectionState{},
+			wantOK: false,
+		},
+		{
+			name:   "unverified client certificate",
+			state:  tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}},
+			wantOK: false,
+		},
+		{
+			name:   "verified client certificate",
+			state:  tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}, VerifiedChains: [][]*x509.Certificate{{cert, ca.cert}}},
+			wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, ok := IdentityFromState(tt.state)
+			if ok != tt.wantOK {
+				t.Fatalf("IdentityFromState() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if identity.CommonName != "billing-service" || identity.Subject != "CN=billing-service" {
+				t.Errorf("identity subject = %q (%q), want CN=billing-service", identity.Subject, identity.CommonName)
+			}
+			if len(identity.DNSNames) != 1 || identity.DNSNames[0] != "localhost" {
+				t.Errorf("identity DNS names = %v, want [localhost]", identity.DNSNames)
+			}
+			if len(identity.IPAddresses) != 1 || identity.IPAddresses[0] != "127.0.0.1" {
+				t.Errorf("identity IP addresses = %v, want [127.0.0.1]", identity.IPAddresses)
+			}
+		})
+	}
+}
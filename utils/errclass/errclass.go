@@ -0,0 +1,264 @@
+package errclass
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+	mssql "github.com/microsoft/go-mssqldb"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+
+	"go.risoftinc.com/xarch/constant"
+)
+
+// Kind is the category of an error, the same for every driver that can return it
+type Kind int
+
+const (
+	Unknown Kind = iota
+	NotFound
+	Conflict
+	Invalid
+	Retryable
+	Unavailable
+	Timeout
+	Canceled
+	Unauthenticated
+	PermissionDenied
+)
+
+var kindNames = map[Kind]string{
+	Unknown:          "unknown",
+	NotFound:         "not_found",
+	Conflict:         "conflict",
+	Invalid:          "invalid",
+	Retryable:        "retryable",
+	Unavailable:      "unavailable",
+	Timeout:          "timeout",
+	Canceled:         "canceled",
+	Unauthenticated:  "unauthenticated",
+	PermissionDenied: "permission_denied",
+}
+
+func (k Kind) String() string {
+	return kindNames[k]
+}
+
+// Class is the classification of an error. Reason is the detailed response key of a failed dependency,
+// e.g. connection_refused, it is empty when the error says no more than its kind.
+type Class struct {
+	Kind   Kind
+	Reason string
+}
+
+// Key returns the response key answering the class to a client, fallback for the kinds a client
+// cannot act on, such as wrong database credentials
+func (c Class) Key(fallback string) string {
+	switch c.Kind {
+	case NotFound:
+		return constant.ErrorNotFound
+	case Conflict:
+		return constant.ErrorConflict
+	case Invalid:
+		return constant.ErrorBadRequest
+	case Retryable, Unavailable:
+		return constant.ErrorServiceUnavailable
+	case Timeout, Canceled:
+		return constant.ErrorTimeout
+	default:
+		return fallback
+	}
+}
+
+// Is reports whether err is classified as kind
+func Is(err error, kind Kind) bool {
+	return Classify(err).Kind == kind
+}
+
+// Key returns the response key of err, fallback when it is not classified
+func Key(err error, fallback string) string {
+	return Classify(err).Key(fallback)
+}
+
+// Classify unwraps err to a driver, sentinel, network or context error and returns its class.
+// Driver errors are matched on their codes, never on their message.
+func Classify(err error) Class {
+	if err == nil {
+		return Class{}
+	}
+
+	for _, classify := range []func(error) (Class, bool){
+		classifyPostgres,
+		classifyMySQL,
+		classifySQLServer,
+		classifySQLite,
+		classifySentinel,
+		classifyNetwork,
+	} {
+		if class, ok := classify(err); ok {
+			return class
+		}
+	}
+	return Class{}
+}
+
+// classifyPostgres matches SQLSTATE codes, CockroachDB returns the same codes
+func classifyPostgres(err error) (Class, bool) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return Class{}, false
+	}
+
+	switch code := pgErr.Code; {
+	case code == "23505" || code == "23503": // unique_violation, foreign_key_violation
+		return Class{Kind: Conflict}, true
+	case code == "23502" || code == "23514" || strings.HasPrefix(code, "22"): // not_null_violation, check_violation, data_exception
+		return Class{Kind: Invalid}, true
+	case code == "40001" || code == "40P01": // serialization_failure, deadlock_detected
+		return Class{Kind: Retryable}, true
+	case code == "57014": // query_canceled, also raised by statement_timeout
+		return Class{Kind: Timeout}, true
+	case code == "53300": // too_many_connections
+		return Class{Kind: Unavailable, Reason: constant.ErrorTooManyConnections}, true
+	case code == "57P01" || code == "57P03" || strings.HasPrefix(code, "08"): // admin_shutdown, cannot_connect_now, connection_exception
+		return Class{Kind: Unavailable, Reason: constant.ErrorConnectionRefused}, true
+	case code == "28P01" || code == "28000": // invalid_password, invalid_authorization_specification
+		return Class{Kind: Unauthenticated, Reason: constant.ErrorAuthFailed}, true
+	case code == "42501" || code == "3D000": // insufficient_privilege, invalid_catalog_name
+		return Class{Kind: PermissionDenied, Reason: constant.ErrorAccessDenied}, true
+	}
+	return Class{}, false
+}
+
+func classifyMySQL(err error) (Class, bool) {
+	if errors.Is(err, mysql.ErrInvalidConn) {
+		return Class{Kind: Unavailable, Reason: constant.ErrorConnectionRefused}, true
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return Class{}, false
+	}
+
+	switch mysqlErr.Number {
+	case 1062, 1586, 1451, 1452: // ER_DUP_ENTRY, ER_DUP_ENTRY_WITH_KEY_NAME, ER_ROW_IS_REFERENCED_2, ER_NO_REFERENCED_ROW_2
+		return Class{Kind: Conflict}, true
+	case 1048, 1264, 1366, 1406: // ER_BAD_NULL_ERROR, ER_WARN_DATA_OUT_OF_RANGE, ER_TRUNCATED_WRONG_VALUE_FOR_FIELD, ER_DATA_TOO_LONG
+		return Class{Kind: Invalid}, true
+	case 1213, 1205: // ER_LOCK_DEADLOCK, ER_LOCK_WAIT_TIMEOUT
+		return Class{Kind: Retryable}, true
+	case 3024: // ER_QUERY_TIMEOUT
+		return Class{Kind: Timeout}, true
+	case 1040, 1203: // ER_CON_COUNT_ERROR, ER_TOO_MANY_USER_CONNECTIONS
+		return Class{Kind: Unavailable, Reason: constant.ErrorTooManyConnections}, true
+	case 1045: // ER_ACCESS_DENIED_ERROR, wrong user or password
+		return Class{Kind: Unauthenticated, Reason: constant.ErrorAuthFailed}, true
+	case 1044, 1049: // ER_DBACCESS_DENIED_ERROR, ER_BAD_DB_ERROR
+		return Class{Kind: PermissionDenied, Reason: constant.ErrorAccessDenied}, true
+	}
+	return Class{}, false
+}
+
+func classifySQLServer(err error) (Class, bool) {
+	var mssqlErr mssql.Error
+	if !errors.As(err, &mssqlErr) {
+		return Class{}, false
+	}
+
+	switch mssqlErr.Number {
+	case 2627, 2601, 547: // unique constraint, unique index, foreign key or check constraint
+		return Class{Kind: Conflict}, true
+	case 515, 2628, 8152: // NULL into a NOT NULL column, string or binary data would be truncated
+		return Class{Kind: Invalid}, true
+	case 1205: // chosen as the deadlock victim
+		return Class{Kind: Retryable}, true
+	case 1222: // lock request time out period exceeded
+		return Class{Kind: Timeout}, true
+	case 10928, 10929: // resource limit of the database reached
+		return Class{Kind: Unavailable, Reason: constant.ErrorTooManyConnections}, true
+	case 18456: // login failed
+		return Class{Kind: Unauthenticated, Reason: constant.ErrorAuthFailed}, true
+	case 4060: // cannot open the requested database
+		return Class{Kind: PermissionDenied, Reason: constant.ErrorAccessDenied}, true
+	}
+	return Class{}, false
+}
+
+func classifySQLite(err error) (Class, bool) {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return Class{}, false
+	}
+
+	switch sqliteErr.ExtendedCode {
+	case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey, sqlite3.ErrConstraintForeignKey:
+		return Class{Kind: Conflict}, true
+	case sqlite3.ErrConstraintNotNull, sqlite3.ErrConstraintCheck:
+		return Class{Kind: Invalid}, true
+	}
+
+	switch sqliteErr.Code {
+	case sqlite3.ErrBusy, sqlite3.ErrLocked:
+		return Class{Kind: Retryable}, true
+	case sqlite3.ErrPerm, sqlite3.ErrAuth, sqlite3.ErrReadonly:
+		return Class{Kind: PermissionDenied, Reason: constant.ErrorAccessDenied}, true
+	case sqlite3.ErrCantOpen, sqlite3.ErrCorrupt, sqlite3.ErrNotADB:
+		return Class{Kind: Unavailable, Reason: constant.ErrorDriverError}, true
+	}
+	return Class{}, false
+}
+
+// classifySentinel matches the errors of gorm, database/sql, go-redis and the MongoDB driver
+func classifySentinel(err error) (Class, bool) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, sql.ErrNoRows),
+		errors.Is(err, redis.Nil), errors.Is(err, mongo.ErrNoDocuments):
+		return Class{Kind: NotFound}, true
+	case errors.Is(err, gorm.ErrDuplicatedKey), errors.Is(err, gorm.ErrForeignKeyViolated), mongo.IsDuplicateKeyError(err):
+		return Class{Kind: Conflict}, true
+	case errors.Is(err, gorm.ErrInvalidData), errors.Is(err, gorm.ErrInvalidValue), errors.Is(err, gorm.ErrInvalidField):
+		return Class{Kind: Invalid}, true
+	case errors.Is(err, sql.ErrConnDone), errors.Is(err, driver.ErrBadConn):
+		return Class{Kind: Unavailable, Reason: constant.ErrorConnectionRefused}, true
+	}
+	return Class{}, false
+}
+
+// classifyNetwork matches DNS, dial, TLS and context errors, which mean the same for every dependency
+func classifyNetwork(err error) (Class, bool) {
+	var (
+		dnsErr       *net.DNSError
+		netErr       net.Error
+		opErr        *net.OpError
+		certErr      *tls.CertificateVerificationError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+	)
+
+	switch {
+	case errors.As(err, &dnsErr):
+		return Class{Kind: Unavailable, Reason: constant.ErrorDnsError}, true
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return Class{Kind: Unavailable, Reason: constant.ErrorConnectionRefused}, true
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout(), mongo.IsTimeout(err):
+		return Class{Kind: Timeout, Reason: constant.ErrorConnectionTimeout}, true
+	case errors.Is(err, context.Canceled):
+		return Class{Kind: Canceled}, true
+	case errors.As(err, &certErr), errors.As(err, &authorityErr), errors.As(err, &hostnameErr):
+		return Class{Kind: Unavailable, Reason: constant.ErrorSslTlsError}, true
+	case errors.As(err, &opErr), mongo.IsNetworkError(err):
+		return Class{Kind: Unavailable}, true
+	}
+	return Class{}, false
+}
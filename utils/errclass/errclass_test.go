@@ -0,0 +1,102 @@
+package errclass
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+	mssql "github.com/microsoft/go-mssqldb"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"go.risoftinc.com/xarch/constant"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Class
+	}{
+		{"nil", nil, Class{}},
+		{"record not found", fmt.Errorf("find user: %w", gorm.ErrRecordNotFound), Class{Kind: NotFound}},
+		{"redis nil", redis.Nil, Class{Kind: NotFound}},
+		{"gorm duplicated key", gorm.ErrDuplicatedKey, Class{Kind: Conflict}},
+		{"postgres unique", &pgconn.PgError{Code: "23505"}, Class{Kind: Conflict}},
+		{"postgres serialization", &pgconn.PgError{Code: "40001"}, Class{Kind: Retryable}},
+		{"postgres auth", &pgconn.PgError{Code: "28P01"}, Class{Kind: Unauthenticated, Reason: constant.ErrorAuthFailed}},
+		{"mysql duplicate entry", &mysql.MySQLError{Number: 1062}, Class{Kind: Conflict}},
+		{"mysql too many connections", &mysql.MySQLError{Number: 1040}, Class{Kind: Unavailable, Reason: constant.ErrorTooManyConnections}},
+		{"sqlserver deadlock", mssql.Error{Number: 1205}, Class{Kind: Retryable}},
+		{"sqlserver unique", mssql.Error{Number: 2627}, Class{Kind: Conflict}},
+		{"sqlite busy", sqlite3.Error{Code: sqlite3.ErrBusy}, Class{Kind: Retryable}},
+		{"dns", &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "db"}}, Class{Kind: Unavailable, Reason: constant.ErrorDnsError}},
+		{"refused", &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, Class{Kind: Unavailable, Reason: constant.ErrorConnectionRefused}},
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), Class{Kind: Timeout, Reason: constant.ErrorConnectionTimeout}},
+		{"canceled", context.Canceled, Class{Kind: Canceled}},
+		{"driver in a table name", errors.New(`no such table: driver_logs, connection timeout`), Class{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(tt.err); got != tt.want {
+				t.Errorf("Classify(%v) = %+v, want %+v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestClassifySQLite runs real statements, so the codes are the ones the driver returns
+func TestClassifySQLite(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.Exec(`CREATE TABLE users (id INTEGER PRIMARY KEY, username TEXT NOT NULL UNIQUE)`).Error; err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	db.Exec(`INSERT INTO users (username) VALUES ('admin')`)
+
+	err = db.Exec(`INSERT INTO users (username) VALUES ('admin')`).Error
+	if kind := Classify(err).Kind; kind != Conflict {
+		t.Errorf("duplicate username classified as %s, want conflict", kind)
+	}
+	err = db.Exec(`INSERT INTO users (username) VALUES (NULL)`).Error
+	if kind := Classify(err).Kind; kind != Invalid {
+		t.Errorf("NULL username classified as %s, want invalid", kind)
+	}
+	err = db.Table("users").Where("id = ?", 42).Take(&map[string]any{}).Error
+	if kind := Classify(err).Kind; kind != NotFound {
+		t.Errorf("missing row classified as %s, want not_found", kind)
+	}
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{gorm.ErrRecordNotFound, constant.ErrorNotFound},
+		{&pgconn.PgError{Code: "23505"}, constant.ErrorConflict},
+		{&pgconn.PgError{Code: "40P01"}, constant.ErrorServiceUnavailable},
+		{context.DeadlineExceeded, constant.ErrorTimeout},
+		// A client cannot act on the credentials of the database
+		{&mysql.MySQLError{Number: 1045}, constant.ErrorDatabase},
+		{errors.New("boom"), constant.ErrorDatabase},
+	}
+	for _, tt := range tests {
+		if got := Key(tt.err, constant.ErrorDatabase); got != tt.want {
+			t.Errorf("Key(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.risoftinc.com/xarch/config"
+)
+
+// Overall and per-dependency statuses
+const (
+	StatusUp       = "up"
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+)
+
+type (
+	// Checker is a named dependency check. Timeout and TTL fall back to the registry defaults when zero.
+	Checker struct {
+		Name string
+		// Check returns nil when the dependency is usable and must respect ctx
+		Check func(ctx context.Context) error
+		// Critical failures make the application down, other failures only degrade it
+		Critical bool
+		// Timeout bounds a single check
+		Timeout time.Duration
+		// TTL is how long a result is reused before the dependency is checked again
+		TTL time.Duration
+	}
+
+	// Result is the outcome of a single checker
+	Result struct {
+		Name      string        `json:"-"`
+		Status    string        `json:"status"`
+		Critical  bool          `json:"critical"`
+		Latency   string        `json:"latency"`
+		LastError string        `json:"last_error,omitempty"`
+		CheckedAt time.Time     `json:"checked_at"`
+		Duration  time.Duration `json:"-"`
+		Err       error         `json:"-"`
+	}
+
+	// Report is the outcome of every registered checker
+	Report struct {
+		Status string            `json:"status"`
+		Checks map[string]Result `json:"checks"`
+	}
+
+	IRegistry interface {
+		Register(checkers ...Checker)
+		Check(ctx context.Context) Report
+	}
+
+	Registry struct {
+		timeout time.Duration
+		ttl     time.Duration
+
+		mu      sync.RWMutex
+		entries map[string]*entry
+	}
+
+	// entry caches the last result of a checker, mu serializes checks of the same dependency
+	entry struct {
+		checker Checker
+		mu      sync.Mutex
+		last    *Result
+	}
+)
+
+func NewRegistry(cfg config.HealthConfig) IRegistry {
+	return &Registry{
+		timeout: cfg.CheckTimeout,
+		ttl:     cfg.CacheTTL,
+		entries: make(map[string]*entry),
+	}
+}
+
+// Register adds checkers to the registry. A checker replaces an earlier one with the same name.
+func (r *Registry) Register(checkers ...Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, checker := range checkers {
+		if checker.Timeout <= 0 {
+			checker.Timeout = r.timeout
+		}
+		if checker.TTL <= 0 {
+			checker.TTL = r.ttl
+		}
+		r.entries[checker.Name] = &entry{checker: checker}
+	}
+}
+
+// Check runs every checker concurrently, reusing results younger than their TTL, and
+// aggregates them: any critical failure is down, any other failure is degraded.
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.RLock()
+	entries := make([]*entry, 0, len(r.entries))
+	for _, e := range r.entries {
+		entries = append(entries, e)
+	}
+	r.mu.RUnlock()
+
+	results := make([]Result, len(entries))
+	var wg sync.WaitGroup
+	for i, e := range entries {
+		wg.Add(1)
+		go func(i int, e *entry) {
+			defer wg.Done()
+			results[i] = e.result(ctx)
+		}(i, e)
+	}
+	wg.Wait()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]Result, len(results)),
+	}
+	for _, result := range results {
+		report.Checks[result.Name] = result
+		if result.Status == StatusUp {
+			continue
+		}
+		if result.Critical {
+			report.Status = StatusDown
+		} else if report.Status == StatusUp {
+			report.Status = StatusDegraded
+		}
+	}
+
+	return report
+}
+
+// Failures returns the failed results ordered by name, critical ones first
+func (report Report) Failures() []Result {
+	var failures []Result
+	for _, result := range report.Checks {
+		if result.Status != StatusUp {
+			failures = append(failures, result)
+		}
+	}
+
+	sort.Slice(failures, func(i, j int) bool {
+		if failures[i].Critical != failures[j].Critical {
+			return failures[i].Critical
+		}
+		return failures[i].Name < failures[j].Name
+	})
+
+	return failures
+}
+
+// result returns the cached result while it is fresh, otherwise checks the dependency again
+func (e *entry) result(ctx context.Context) Result {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.last != nil && time.Since(e.last.CheckedAt) < e.checker.TTL {
+		return *e.last
+	}
+
+	result := e.run(ctx)
+	e.last = &result
+
+	return result
+}
+
+func (e *entry) run(ctx context.Context) Result {
+	ctx, cancel := context.WithTimeout(ctx, e.checker.Timeout)
+	defer cancel()
+
+	started := time.Now()
+	err := e.check(ctx)
+	duration := time.Since(started)
+
+	result := Result{
+		Name:      e.checker.Name,
+		Status:    StatusUp,
+		Critical:  e.checker.Critical,
+		Latency:   duration.String(),
+		CheckedAt: started,
+		Duration:  duration,
+	}
+
+	if err != nil {
+		result.Err = err
+		result.LastError = err.Error()
+		result.Status = StatusDegraded
+		if e.checker.Critical {
+			result.Status = StatusDown
+		}
+	}
+
+	return result
+}
+
+// check runs the checker and reports a timeout even when the checker ignores ctx
+func (e *entry) check(ctx context.Context) (err error) {
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("health check panicked: %v", r)
+			}
+		}()
+		done <- e.checker.Check(ctx)
+	}()
+
+	select {
+	case err = <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("health check timed out after %s: %w", e.checker.Timeout, ctx.Err())
+	}
+}
@@ -0,0 +1,135 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.risoftinc.com/xarch/config"
+)
+
+func failing(err error) func(ctx context.Context) error {
+	return func(ctx context.Context) error { return err }
+}
+
+func passing(ctx context.Context) error { return nil }
+
+func TestRegistryCheckStatus(t *testing.T) {
+	errDown := errors.New("connection refused")
+
+	tests := []struct {
+		name     string
+		checkers []Checker
+		want     string
+		wantDown []string
+	}{
+		{
+			name:     "no checkers",
+			checkers: nil,
+			want:     StatusUp,
+		},
+		{
+			name: "all passing",
+			checkers: []Checker{
+				{Name: "database", Critical: true, Check: passing},
+				{Name: "redis", Check: passing},
+			},
+			want: StatusUp,
+		},
+		{
+			name: "non critical failure degrades",
+			checkers: []Checker{
+				{Name: "database", Critical: true, Check: passing},
+				{Name: "redis", Check: failing(errDown)},
+			},
+			want:     StatusDegraded,
+			wantDown: []string{"redis"},
+		},
+		{
+			name: "critical failure is down",
+			checkers: []Checker{
+				{Name: "database", Critical: true, Check: failing(errDown)},
+				{Name: "redis", Check: failing(errDown)},
+			},
+			want:     StatusDown,
+			wantDown: []string{"database", "redis"},
+		},
+		{
+			name: "timeout counts as failure",
+			checkers: []Checker{
+				{Name: "mongodb", Critical: true, Timeout: 10 * time.Millisecond, Check: func(ctx context.Context) error {
+					time.Sleep(time.Second)
+					return nil
+				}},
+			},
+			want:     StatusDown,
+			wantDown: []string{"mongodb"},
+		},
+		{
+			name: "panic counts as failure",
+			checkers: []Checker{
+				{Name: "redis", Check: func(ctx context.Context) error { panic("nil client") }},
+			},
+			want:     StatusDegraded,
+			wantDown: []string{"redis"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry(config.HealthConfig{CheckTimeout: time.Second})
+			registry.Register(tt.checkers...)
+
+			report := registry.Check(context.Background())
+			if report.Status != tt.want {
+				t.Errorf("Check() status = %q, want %q", report.Status, tt.want)
+			}
+			if len(report.Checks) != len(tt.checkers) {
+				t.Errorf("Check() reported %d checks, want %d", len(report.Checks), len(tt.checkers))
+			}
+
+			var gotDown []string
+			for _, result := range report.Failures() {
+				if result.LastError == "" {
+					t.Errorf("failed check %q has no last error", result.Name)
+				}
+				gotDown = append(gotDown, result.Name)
+			}
+			if len(gotDown) != len(tt.wantDown) {
+				t.Fatalf("failures = %v, want %v", gotDown, tt.wantDown)
+			}
+			for i := range gotDown {
+				if gotDown[i] != tt.wantDown[i] {
+					t.Errorf("failures = %v, want %v", gotDown, tt.wantDown)
+				}
+			}
+		})
+	}
+}
+
+func TestRegistryCachesResults(t *testing.T) {
+	var calls atomic.Int32
+	registry := NewRegistry(config.HealthConfig{CheckTimeout: time.Second})
+	registry.Register(
+		Checker{Name: "cached", TTL: time.Hour, Check: func(ctx context.Context) error {
+			calls.Add(1)
+			return nil
+		}},
+		Checker{Name: "uncached", TTL: time.Nanosecond, Check: passing},
+	)
+
+	first := registry.Check(context.Background())
+	second := registry.Check(context.Background())
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("cached checker ran %d times, want 1", got)
+	}
+	if !first.Checks["cached"].CheckedAt.Equal(second.Checks["cached"].CheckedAt) {
+		t.Error("cached result was refreshed before its TTL")
+	}
+	if first.Checks["uncached"].CheckedAt.Equal(second.Checks["uncached"].CheckedAt) {
+		t.Error("uncached result was reused after its TTL")
+	}
+}
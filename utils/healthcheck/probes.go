@@ -0,0 +1,95 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Reasons reported when the application is not ready
+const (
+	ReasonStarting = "starting"
+	ReasonDraining = "draining"
+)
+
+type (
+	// IProbes answers the liveness, startup and readiness questions asked by orchestrators and load balancers.
+	// Liveness never depends on the probes: a process that can answer is alive.
+	IProbes interface {
+		// Started reports whether every component finished starting
+		Started() bool
+		// Ready reports whether traffic should be routed here, with the reason when it should not
+		Ready(ctx context.Context) (bool, string)
+		MarkStarted()
+		MarkDraining()
+		// Subscribe registers fn to be called after every state change
+		Subscribe(fn func())
+	}
+
+	Probes struct {
+		registry IRegistry
+		started  atomic.Bool
+		draining atomic.Bool
+
+		mu          sync.Mutex
+		subscribers []func()
+	}
+)
+
+func NewProbes(registry IRegistry) IProbes {
+	return &Probes{
+		registry: registry,
+	}
+}
+
+func (p *Probes) Started() bool {
+	return p.started.Load()
+}
+
+// Ready is false until startup completes, as soon as draining starts and while a critical dependency is down
+func (p *Probes) Ready(ctx context.Context) (bool, string) {
+	if p.draining.Load() {
+		return false, ReasonDraining
+	}
+	if !p.started.Load() {
+		return false, ReasonStarting
+	}
+
+	report := p.registry.Check(ctx)
+	if report.Status == StatusDown {
+		return false, fmt.Sprintf("%s is down", report.Failures()[0].Name)
+	}
+
+	return true, ""
+}
+
+// MarkStarted is called once every component has started
+func (p *Probes) MarkStarted() {
+	if !p.started.Swap(true) {
+		p.notify()
+	}
+}
+
+// MarkDraining is called at the beginning of a graceful shutdown, readiness stays false afterwards
+func (p *Probes) MarkDraining() {
+	if !p.draining.Swap(true) {
+		p.notify()
+	}
+}
+
+func (p *Probes) Subscribe(fn func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.subscribers = append(p.subscribers, fn)
+}
+
+func (p *Probes) notify() {
+	p.mu.Lock()
+	subscribers := append([]func(){}, p.subscribers...)
+	p.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn()
+	}
+}
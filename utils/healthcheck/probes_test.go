@@ -0,0 +1,88 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.risoftinc.com/xarch/config"
+)
+
+func TestProbesReady(t *testing.T) {
+	tests := []struct {
+		name       string
+		check      func(ctx context.Context) error
+		started    bool
+		draining   bool
+		want       bool
+		wantReason string
+	}{
+		{
+			name:       "starting",
+			check:      passing,
+			want:       false,
+			wantReason: ReasonStarting,
+		},
+		{
+			name:    "started",
+			check:   passing,
+			started: true,
+			want:    true,
+		},
+		{
+			name:       "critical dependency down",
+			check:      failing(errors.New("connection refused")),
+			started:    true,
+			want:       false,
+			wantReason: "database is down",
+		},
+		{
+			name:       "draining",
+			check:      passing,
+			started:    true,
+			draining:   true,
+			want:       false,
+			wantReason: ReasonDraining,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry(config.HealthConfig{CheckTimeout: time.Second})
+			registry.Register(Checker{Name: "database", Critical: true, Check: tt.check})
+			probes := NewProbes(registry)
+
+			if tt.started {
+				probes.MarkStarted()
+			}
+			if tt.draining {
+				probes.MarkDraining()
+			}
+
+			ready, reason := probes.Ready(context.Background())
+			if ready != tt.want || reason != tt.wantReason {
+				t.Errorf("Ready() = (%v, %q), want (%v, %q)", ready, reason, tt.want, tt.wantReason)
+			}
+			if probes.Started() != tt.started {
+				t.Errorf("Started() = %v, want %v", probes.Started(), tt.started)
+			}
+		})
+	}
+}
+
+func TestProbesNotifySubscribersOnce(t *testing.T) {
+	probes := NewProbes(NewRegistry(config.HealthConfig{CheckTimeout: time.Second}))
+
+	var calls int
+	probes.Subscribe(func() { calls++ })
+
+	probes.MarkStarted()
+	probes.MarkStarted()
+	probes.MarkDraining()
+	probes.MarkDraining()
+
+	if calls != 2 {
+		t.Errorf("subscriber called %d times, want 2", calls)
+	}
+}
@@ -0,0 +1,134 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go.risoftinc.com/xarch/utils/errclass"
+)
+
+// lockPollInterval is how often a run retries a lock held by another instance
+const lockPollInterval = 500 * time.Millisecond
+
+type (
+	// sessionLock is an advisory lock of the database, held by the connection that took it
+	sessionLock struct {
+		acquire string
+		release string
+		args    []any
+	}
+
+	// lockRecord is the single row of the lock table of databases without advisory locks
+	lockRecord struct {
+		ID       int       `gorm:"primaryKey;autoIncrement:false"`
+		Owner    string    `gorm:"size:36;not null"`
+		LockedAt time.Time `gorm:"not null"`
+	}
+)
+
+// lock waits up to LockTimeout until no other instance runs migrations on the database.
+// PostgreSQL, MySQL and SQL Server use an advisory lock, which the database drops with the
+// connection of a crashed run. CockroachDB and SQLite use a row of the <table>_lock table.
+func (m *Migrator) lock(ctx context.Context) (func(), error) {
+	waitCtx, cancel := context.WithTimeout(ctx, m.opts.LockTimeout)
+	defer cancel()
+
+	name := m.opts.Table
+	switch m.opts.Dialect {
+	case "postgres":
+		hash := fnv.New64a()
+		hash.Write([]byte(name))
+		key := int64(hash.Sum64())
+		return m.sessionLock(waitCtx, sessionLock{
+			acquire: "SELECT pg_try_advisory_lock($1)",
+			release: "SELECT pg_advisory_unlock($1)",
+			args:    []any{key},
+		})
+	case "mysql":
+		return m.sessionLock(waitCtx, sessionLock{
+			acquire: "SELECT GET_LOCK(?, 0)",
+			release: "SELECT RELEASE_LOCK(?)",
+			args:    []any{name},
+		})
+	case "sqlserver":
+		return m.sessionLock(waitCtx, sessionLock{
+			acquire: "DECLARE @result int; " +
+				"EXEC @result = sp_getapplock @Resource = @resource, @LockMode = 'Exclusive', @LockOwner = 'Session', @LockTimeout = 0; " +
+				"SELECT CASE WHEN @result >= 0 THEN 1 ELSE 0 END",
+			release: "EXEC sp_releaseapplock @Resource = @resource, @LockOwner = 'Session'",
+			args:    []any{sql.Named("resource", name)},
+		})
+	default:
+		return m.tableLock(waitCtx, name+"_lock")
+	}
+}
+
+// sessionLock takes the lock on a dedicated connection of the primary and keeps it until the run ends
+func (m *Migrator) sessionLock(ctx context.Context, lock sessionLock) (func(), error) {
+	sqlDB, err := m.db.DB()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a connection for the migration lock: %w", err)
+	}
+
+	for {
+		var acquired sql.NullBool
+		if err := conn.QueryRowContext(ctx, lock.acquire, lock.args...).Scan(&acquired); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to take the migration lock: %w", err)
+		}
+		if acquired.Bool {
+			return func() {
+				conn.ExecContext(context.Background(), lock.release, lock.args...)
+				conn.Close()
+			}, nil
+		}
+
+		if err := waitLock(ctx, m.opts.Table); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+}
+
+// tableLock inserts the lock row, a run that crashed leaves it behind until it is deleted by hand
+func (m *Migrator) tableLock(ctx context.Context, table string) (func(), error) {
+	if err := m.ensureTable(ctx, table, &lockRecord{}); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", table, err)
+	}
+
+	owner := uuid.NewString()
+	for {
+		err := m.db.WithContext(ctx).Table(table).Create(&lockRecord{ID: 1, Owner: owner, LockedAt: time.Now().UTC()}).Error
+		if err == nil {
+			return func() {
+				m.db.Table(table).Where("id = ? AND owner = ?", 1, owner).Delete(&lockRecord{})
+			}, nil
+		}
+		if !errclass.Is(err, errclass.Conflict) {
+			return nil, fmt.Errorf("failed to take the migration lock: %w", err)
+		}
+
+		if err := waitLock(ctx, table); err != nil {
+			return nil, fmt.Errorf("%w, delete its row if no migration runs", err)
+		}
+	}
+}
+
+// waitLock sleeps before the next attempt, it fails once the lock timeout is over
+func waitLock(ctx context.Context, lock string) error {
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for the migration lock %s: %w", lock, ctx.Err())
+	case <-time.After(lockPollInterval):
+		return nil
+	}
+}
@@ -0,0 +1,318 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration states reported by Status
+const (
+	StateApplied = "applied"
+	StatePending = "pending"
+	// StateDrift is an applied migration whose up file changed since it ran
+	StateDrift = "drift"
+	// StateMissing is an applied migration without files, e.g. removed or from another branch
+	StateMissing = "missing"
+)
+
+type (
+	Options struct {
+		// Dialect is the DB_TYPE, the folder of the file system holding its migrations
+		Dialect string
+		// Sets are the subfolders applied together in version order, e.g. ddl and dml
+		Sets []string
+		// Table records the applied migrations, the lock of a run is named after it
+		Table string
+		// LockTimeout is how long a run waits for the run of another instance
+		LockTimeout time.Duration
+	}
+
+	// Migration is a pair of <version>_<name>.up.sql and .down.sql files, Checksum is the SHA-256 of the up file
+	Migration struct {
+		Version  string
+		Set      string
+		Name     string
+		Checksum string
+		up       string
+		down     string
+	}
+
+	Status struct {
+		Version   string
+		Set       string
+		Name      string
+		State     string
+		AppliedAt *time.Time
+	}
+
+	// DriftError reports applied migrations whose up file changed, nothing runs until the files are restored
+	DriftError struct {
+		Table    string
+		Versions []string
+	}
+
+	IMigrator interface {
+		// Up applies every pending migration
+		Up(ctx context.Context) (int, error)
+		// Down reverts the last steps applied migrations
+		Down(ctx context.Context, steps int) (int, error)
+		// Goto applies or reverts migrations until version is the last one applied, "0" reverts all of them
+		Goto(ctx context.Context, version string) (int, error)
+		// Status lists the migrations of the files and of the version table
+		Status(ctx context.Context) ([]Status, error)
+	}
+
+	Migrator struct {
+		db   *gorm.DB
+		fsys fs.FS
+		opts Options
+	}
+
+	// record is a row of the version table
+	record struct {
+		Version   string    `gorm:"primaryKey;size:32"`
+		Set       string    `gorm:"column:set_name;size:16;not null"`
+		Name      string    `gorm:"size:255;not null"`
+		Checksum  string    `gorm:"size:64;not null"`
+		AppliedAt time.Time `gorm:"not null"`
+	}
+
+	// plan picks the migrations a run reverts, newest first, and applies, oldest first
+	plan func(migrations []Migration, applied map[string]record) (revert, apply []Migration, err error)
+)
+
+func (e *DriftError) Error() string {
+	return fmt.Sprintf("migrations %s changed after they were applied, restore the files or update the checksum in %s",
+		strings.Join(e.Versions, ", "), e.Table)
+}
+
+// NewMigrator runs the migrations of fsys, laid out as <dialect>/<set>/<version>_<name>.up.sql
+func NewMigrator(db *gorm.DB, fsys fs.FS, opts Options) *Migrator {
+	return &Migrator{db: db, fsys: fsys, opts: opts}
+}
+
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	return m.run(ctx, func(migrations []Migration, applied map[string]record) ([]Migration, []Migration, error) {
+		var apply []Migration
+		for _, migration := range migrations {
+			if _, ok := applied[migration.Version]; !ok {
+				apply = append(apply, migration)
+			}
+		}
+		return nil, apply, nil
+	})
+}
+
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if steps < 1 {
+		return 0, fmt.Errorf("down needs at least one step, got %d", steps)
+	}
+
+	return m.run(ctx, func(migrations []Migration, applied map[string]record) ([]Migration, []Migration, error) {
+		versions := appliedVersions(applied)
+		revert, err := m.reverting(migrations, versions[:min(steps, len(versions))])
+		return revert, nil, err
+	})
+}
+
+func (m *Migrator) Goto(ctx context.Context, version string) (int, error) {
+	return m.run(ctx, func(migrations []Migration, applied map[string]record) ([]Migration, []Migration, error) {
+		known := version == "0" || slices.ContainsFunc(migrations, func(migration Migration) bool { return migration.Version == version })
+		if _, ok := applied[version]; !known && !ok {
+			return nil, nil, fmt.Errorf("unknown migration version %s", version)
+		}
+
+		var newer []string
+		for _, v := range appliedVersions(applied) {
+			if compareVersions(v, version) > 0 {
+				newer = append(newer, v)
+			}
+		}
+		revert, err := m.reverting(migrations, newer)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var apply []Migration
+		for _, migration := range migrations {
+			if _, ok := applied[migration.Version]; !ok && compareVersions(migration.Version, version) <= 0 {
+				apply = append(apply, migration)
+			}
+		}
+		return revert, apply, nil
+	})
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	migrations, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(migrations))
+	for _, migration := range migrations {
+		status := Status{Version: migration.Version, Set: migration.Set, Name: migration.Name, State: StatePending}
+		if rec, ok := applied[migration.Version]; ok {
+			status.State, status.AppliedAt = StateApplied, &rec.AppliedAt
+			if rec.Checksum != migration.Checksum {
+				status.State = StateDrift
+			}
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, rec := range applied {
+		statuses = append(statuses, Status{Version: rec.Version, Set: rec.Set, Name: rec.Name, State: StateMissing, AppliedAt: &rec.AppliedAt})
+	}
+
+	slices.SortFunc(statuses, func(a, b Status) int { return compareVersions(a.Version, b.Version) })
+	return statuses, nil
+}
+
+// run holds the migration lock while it plans and runs the migrations, every migration runs in its own transaction.
+// MySQL commits DDL implicitly, a failed MySQL migration can leave part of its statements applied.
+func (m *Migrator) run(ctx context.Context, plan plan) (int, error) {
+	migrations, err := m.load()
+	if err != nil {
+		return 0, err
+	}
+
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	if err := m.ensureTable(ctx, m.opts.Table, &record{}); err != nil {
+		return 0, fmt.Errorf("failed to create %s: %w", m.opts.Table, err)
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var drifted []string
+	for _, migration := range migrations {
+		if rec, ok := applied[migration.Version]; ok && rec.Checksum != migration.Checksum {
+			drifted = append(drifted, migration.Version)
+		}
+	}
+	if len(drifted) > 0 {
+		return 0, &DriftError{Table: m.opts.Table, Versions: drifted}
+	}
+
+	revert, apply, err := plan(migrations, applied)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, migration := range revert {
+		if err := m.exec(ctx, migration.down, func(tx *gorm.DB) error {
+			return tx.Table(m.opts.Table).Where("version = ?", migration.Version).Delete(&record{}).Error
+		}); err != nil {
+			return count, fmt.Errorf("failed to revert migration %s_%s: %w", migration.Version, migration.Name, err)
+		}
+		count++
+	}
+	for _, migration := range apply {
+		if err := m.exec(ctx, migration.up, func(tx *gorm.DB) error {
+			return tx.Table(m.opts.Table).Create(&record{
+				Version:   migration.Version,
+				Set:       migration.Set,
+				Name:      migration.Name,
+				Checksum:  migration.Checksum,
+				AppliedAt: time.Now().UTC(),
+			}).Error
+		}); err != nil {
+			return count, fmt.Errorf("failed to apply migration %s_%s: %w", migration.Version, migration.Name, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// exec runs the statements of a migration file and updates the version table in one transaction
+func (m *Migrator) exec(ctx context.Context, sql string, track func(tx *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, statement := range splitStatements(sql) {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return track(tx)
+	})
+}
+
+// reverting returns the migrations of the applied versions, a version without files cannot be reverted
+func (m *Migrator) reverting(migrations []Migration, versions []string) ([]Migration, error) {
+	revert := make([]Migration, 0, len(versions))
+	for _, version := range versions {
+		idx := slices.IndexFunc(migrations, func(migration Migration) bool { return migration.Version == version })
+		if idx < 0 {
+			return nil, fmt.Errorf("migration %s is applied but has no files in %s", version, m.opts.Dialect)
+		}
+		revert = append(revert, migrations[idx])
+	}
+	return revert, nil
+}
+
+// applied reads the version table. Reads in a transaction go to the primary, never to a read replica.
+func (m *Migrator) applied(ctx context.Context) (map[string]record, error) {
+	var records []record
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if !tx.Migrator().HasTable(m.opts.Table) {
+			return nil
+		}
+		return tx.Table(m.opts.Table).Find(&records).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", m.opts.Table, err)
+	}
+
+	applied := make(map[string]record, len(records))
+	for _, rec := range records {
+		applied[rec.Version] = rec
+	}
+	return applied, nil
+}
+
+// ensureTable creates a table of the runner, another instance may have created it at the same time
+func (m *Migrator) ensureTable(ctx context.Context, table string, model any) error {
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Table(table).AutoMigrate(model)
+	})
+	if err != nil && m.db.WithContext(ctx).Migrator().HasTable(table) {
+		return nil
+	}
+	return err
+}
+
+// appliedVersions returns the applied versions, newest first
+func appliedVersions(applied map[string]record) []string {
+	versions := make([]string, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	slices.SortFunc(versions, func(a, b string) int { return compareVersions(b, a) })
+	return versions
+}
+
+// compareVersions orders numeric versions of any length
+func compareVersions(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,209 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"go.risoftinc.com/xarch/database/migration"
+	"go.risoftinc.com/xarch/utils/testdb"
+	"gorm.io/gorm"
+)
+
+// newTestMigrator runs the SQLite migrations of the repository on an in-memory database
+func newTestMigrator(t *testing.T, fsys fs.FS) (*Migrator, *gorm.DB) {
+	t.Helper()
+
+	db := testdb.Open(t)
+
+	return NewMigrator(db, fsys, Options{
+		Dialect:     "sqlite",
+		Sets:        []string{"ddl", "dml"},
+		Table:       "schema_migrations",
+		LockTimeout: time.Second,
+	}), db
+}
+
+func states(t *testing.T, m *Migrator) string {
+	t.Helper()
+
+	statuses, err := m.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	var got []string
+	for _, status := range statuses {
+		got = append(got, status.State)
+	}
+	return strings.Join(got, ",")
+}
+
+func TestMigratorUpDownGoto(t *testing.T) {
+	ctx := context.Background()
+	m, db := newTestMigrator(t, migration.Files)
+
+	if got := states(t, m); got != "pending,pending,pending,pending" {
+		t.Fatalf("states before up = %s", got)
+	}
+
+	count, err := m.Up(ctx)
+	if err != nil || count != 4 {
+		t.Fatalf("Up() = %d, %v, want 4 migrations", count, err)
+	}
+	var users int64
+	db.Table("users").Count(&users)
+	if users == 0 {
+		t.Error("Up() did not seed the users")
+	}
+	if count, err := m.Up(ctx); err != nil || count != 0 {
+		t.Errorf("second Up() = %d, %v, want nothing to apply", count, err)
+	}
+
+	if count, err := m.Down(ctx, 1); err != nil || count != 1 {
+		t.Fatalf("Down(1) = %d, %v", count, err)
+	}
+	if got := states(t, m); got != "applied,applied,applied,pending" {
+		t.Errorf("states after down = %s", got)
+	}
+	if db.Migrator().HasTable("revoked_tokens") {
+		t.Error("Down(1) left the revoked_tokens table")
+	}
+
+	if count, err := m.Goto(ctx, "20250907140802083"); err != nil || count != 2 {
+		t.Fatalf("Goto(first) = %d, %v, want the seed and refresh_tokens reverted", count, err)
+	}
+	if got := states(t, m); got != "applied,pending,pending,pending" {
+		t.Errorf("states after goto = %s", got)
+	}
+
+	if count, err := m.Goto(ctx, "0"); err != nil || count != 1 {
+		t.Fatalf("Goto(0) = %d, %v", count, err)
+	}
+	if db.Migrator().HasTable("users") {
+		t.Error("Goto(0) left the users table")
+	}
+
+	if _, err := m.Goto(ctx, "1"); err == nil {
+		t.Error("Goto() of an unknown version succeeded")
+	}
+	if _, err := m.Down(ctx, 0); err == nil {
+		t.Error("Down(0) succeeded")
+	}
+}
+
+func TestMigratorDrift(t *testing.T) {
+	ctx := context.Background()
+	fsys := fstest.MapFS{
+		"sqlite/ddl/1_create_items.up.sql":   {Data: []byte("CREATE TABLE items (id INTEGER PRIMARY KEY);")},
+		"sqlite/ddl/1_create_items.down.sql": {Data: []byte("DROP TABLE items;")},
+	}
+	m, _ := newTestMigrator(t, fsys)
+
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	fsys["sqlite/ddl/1_create_items.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT);")}
+	fsys["sqlite/ddl/2_create_tags.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE tags (id INTEGER PRIMARY KEY);")}
+	fsys["sqlite/ddl/2_create_tags.down.sql"] = &fstest.MapFile{Data: []byte("DROP TABLE tags;")}
+
+	var driftErr *DriftError
+	if _, err := m.Up(ctx); !errors.As(err, &driftErr) || !slices.Equal(driftErr.Versions, []string{"1"}) {
+		t.Fatalf("Up() error = %v, want drift of version 1", err)
+	}
+	if got := states(t, m); got != "drift,pending" {
+		t.Errorf("states = %s, want drift,pending", got)
+	}
+
+	delete(fsys, "sqlite/ddl/1_create_items.up.sql")
+	delete(fsys, "sqlite/ddl/1_create_items.down.sql")
+	if got := states(t, m); got != "missing,pending" {
+		t.Errorf("states = %s, want missing,pending", got)
+	}
+	if _, err := m.Down(ctx, 1); err == nil {
+		t.Error("Down() reverted a migration without files")
+	}
+}
+
+func TestMigratorLock(t *testing.T) {
+	m, db := newTestMigrator(t, migration.Files)
+	m.opts.LockTimeout = 50 * time.Millisecond
+
+	if err := db.Table("schema_migrations_lock").AutoMigrate(&lockRecord{}); err != nil {
+		t.Fatalf("failed to create lock table: %v", err)
+	}
+	if err := db.Table("schema_migrations_lock").Create(&lockRecord{ID: 1, Owner: "other", LockedAt: time.Now()}).Error; err != nil {
+		t.Fatalf("failed to take lock: %v", err)
+	}
+
+	if _, err := m.Up(context.Background()); err == nil || !strings.Contains(err.Error(), "timed out waiting for the migration lock") {
+		t.Fatalf("Up() error = %v, want lock timeout", err)
+	}
+
+	db.Table("schema_migrations_lock").Where("owner = ?", "other").Delete(&lockRecord{})
+	if _, err := m.Up(context.Background()); err != nil {
+		t.Fatalf("Up() after release error = %v", err)
+	}
+	var locks int64
+	db.Table("schema_migrations_lock").Count(&locks)
+	if locks != 0 {
+		t.Errorf("Up() left %d lock rows", locks)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	at := time.Date(2026, 10, 17, 12, 30, 45, 123*int(time.Millisecond), time.UTC)
+
+	paths, err := Create(dir, "postgres", "ddl", "Add Orders-Table", at)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	want := []string{
+		filepath.Join(dir, "postgres", "ddl", "20261017123045123_add_orders_table.up.sql"),
+		filepath.Join(dir, "postgres", "ddl", "20261017123045123_add_orders_table.down.sql"),
+	}
+	if !slices.Equal(paths, want) {
+		t.Fatalf("Create() = %v, want %v", paths, want)
+	}
+	for _, path := range paths {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Create() did not write %s: %v", path, err)
+		}
+	}
+
+	if _, err := Create(dir, "postgres", "ddl", " - ", at); err == nil {
+		t.Error("Create() accepted a name without letters")
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want []string
+	}{
+		{"statements", "CREATE TABLE a (id INT);\nCREATE TABLE b (id INT);\n", []string{"CREATE TABLE a (id INT)", "CREATE TABLE b (id INT)"}},
+		{"semicolon in string", "INSERT INTO a VALUES ('x;y');", []string{"INSERT INTO a VALUES ('x;y')"}},
+		{"semicolon in identifiers", "SELECT \"a;\", `b;`, [c;] FROM t", []string{"SELECT \"a;\", `b;`, [c;] FROM t"}},
+		{"comments only", "-- nothing; here\n/* nor; here */\n", nil},
+		{"comment before statement", "-- drop;\nDROP TABLE a;", []string{"-- drop;\nDROP TABLE a"}},
+		{"dollar quoted body", "CREATE FUNCTION f() RETURNS int AS $body$ SELECT 1; $body$ LANGUAGE sql;", []string{"CREATE FUNCTION f() RETURNS int AS $body$ SELECT 1; $body$ LANGUAGE sql"}},
+		{"parameter is not a dollar quote", "SELECT $1; SELECT 2", []string{"SELECT $1", "SELECT 2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitStatements(tt.sql); !slices.Equal(got, tt.want) {
+				t.Errorf("splitStatements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
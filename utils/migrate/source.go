@@ -0,0 +1,179 @@
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	errNoMigrations = errors.New("no migrations")
+	upFilePattern   = regexp.MustCompile(`^(\d+)_(\w+)\.up\.sql$`)
+	nameSeparators  = regexp.MustCompile(`\W+`)
+)
+
+// Create writes an empty up and down file of a new migration to <dir>/<dialect>/<set>, the version is the
+// creation time with milliseconds, e.g. 20250907140802083. It returns the paths of the files.
+func Create(dir, dialect, set, name string, at time.Time) ([]string, error) {
+	name = strings.Trim(nameSeparators.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return nil, errors.New("migration name needs letters or digits")
+	}
+
+	folder := filepath.Join(dir, dialect, set)
+	if err := os.MkdirAll(folder, 0o755); err != nil {
+		return nil, err
+	}
+
+	version := at.Format("20060102150405") + fmt.Sprintf("%03d", at.Nanosecond()/int(time.Millisecond))
+	var paths []string
+	for _, direction := range []string{"up", "down"} {
+		file := filepath.Join(folder, fmt.Sprintf("%s_%s.%s.sql", version, name, direction))
+		if err := os.WriteFile(file, nil, 0o644); err != nil {
+			return nil, err
+		}
+		paths = append(paths, file)
+	}
+	return paths, nil
+}
+
+// load reads the migrations of the dialect from every set, ordered by version.
+// A set without a folder has no migrations, a dialect without a folder is an error.
+func (m *Migrator) load() ([]Migration, error) {
+	if _, err := fs.Stat(m.fsys, m.opts.Dialect); err != nil {
+		return nil, fmt.Errorf("%w for %s: %w", errNoMigrations, m.opts.Dialect, err)
+	}
+
+	var migrations []Migration
+	sets := make(map[string]string)
+	for _, set := range m.opts.Sets {
+		dir := path.Join(m.opts.Dialect, set)
+		entries, err := fs.ReadDir(m.fsys, dir)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			match := upFilePattern.FindStringSubmatch(entry.Name())
+			if entry.IsDir() || match == nil {
+				continue
+			}
+			version, name := match[1], match[2]
+			if other, ok := sets[version]; ok {
+				return nil, fmt.Errorf("migration version %s is used in %s and %s", version, other, dir)
+			}
+			sets[version] = dir
+
+			up, err := fs.ReadFile(m.fsys, path.Join(dir, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+			down, err := fs.ReadFile(m.fsys, path.Join(dir, version+"_"+name+".down.sql"))
+			if err != nil {
+				return nil, fmt.Errorf("migration %s_%s has no down file: %w", version, name, err)
+			}
+
+			sum := sha256.Sum256(up)
+			migrations = append(migrations, Migration{
+				Version:  version,
+				Set:      set,
+				Name:     name,
+				Checksum: hex.EncodeToString(sum[:]),
+				up:       string(up),
+				down:     string(down),
+			})
+		}
+	}
+
+	slices.SortFunc(migrations, func(a, b Migration) int { return compareVersions(a.Version, b.Version) })
+	return migrations, nil
+}
+
+// splitStatements splits a migration file on semicolons outside of quotes, comments and PostgreSQL
+// dollar-quoted bodies, because not every driver runs several statements in one call
+func splitStatements(sql string) []string {
+	var (
+		statements []string
+		current    strings.Builder
+		hasCode    bool
+	)
+	flush := func() {
+		if hasCode {
+			statements = append(statements, strings.TrimSpace(current.String()))
+		}
+		current.Reset()
+		hasCode = false
+	}
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		end := i + 1
+
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end = closing(sql, i+1, string(c))
+		case c == '[':
+			end = closing(sql, i+1, "]")
+		case c == '-' && strings.HasPrefix(sql[i:], "--"):
+			end = closing(sql, i, "\n")
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			end = closing(sql, i+2, "*/")
+		case c == '$':
+			if tag := dollarTag(sql[i:]); tag != "" {
+				end = closing(sql, i+len(tag), tag)
+			}
+		case c == ';':
+			flush()
+			continue
+		}
+
+		comment := c == '-' && strings.HasPrefix(sql[i:], "--") || c == '/' && strings.HasPrefix(sql[i:], "/*")
+		if !comment && !isSpace(c) {
+			hasCode = true
+		}
+		current.WriteString(sql[i:end])
+		i = end - 1
+	}
+	flush()
+
+	return statements
+}
+
+// closing returns the index after the end delimiter searched from start, the end of sql when it is missing
+func closing(sql string, start int, delimiter string) int {
+	if idx := strings.Index(sql[start:], delimiter); idx >= 0 {
+		return start + idx + len(delimiter)
+	}
+	return len(sql)
+}
+
+// dollarTag returns the $tag$ or $$ opening a dollar-quoted string, empty for a $1 parameter
+func dollarTag(sql string) string {
+	for i := 1; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '$':
+			return sql[:i+1]
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 1 && c >= '0' && c <= '9':
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,270 @@
+package query
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Filter operators
+const (
+	OpEq   Operator = "eq"
+	OpNe   Operator = "ne"
+	OpGt   Operator = "gt"
+	OpGte  Operator = "gte"
+	OpLt   Operator = "lt"
+	OpLte  Operator = "lte"
+	OpLike Operator = "like"
+	OpIn   Operator = "in"
+)
+
+// Field types, filter values and cursor values are converted to the type of their field
+const (
+	String FieldType = iota
+	Int
+	Uint
+	Float
+	Bool
+	Time
+)
+
+// Pagination modes
+const (
+	PageMode   Mode = "page"
+	CursorMode Mode = "cursor"
+)
+
+const (
+	DefaultPerPage = 10
+	MaxPerPage     = 100
+	maxInValues    = 100
+)
+
+type (
+	Operator  string
+	FieldType int
+	Mode      string
+
+	// Field is a column callers may filter or sort on
+	Field struct {
+		// Column defaults to the name of the field
+		Column string
+		Type   FieldType
+		// Operators defaults to the operators that make sense for the type
+		Operators []Operator
+		Sortable  bool
+	}
+
+	// Schema whitelists the fields of a model that a query may use, anything else is rejected
+	Schema struct {
+		Fields map[string]Field
+		// DefaultSort is used when the query has no sort
+		DefaultSort []Sort
+		// Key is a unique sortable field appended to every sort so pages are stable, defaults to id
+		Key            string
+		DefaultPerPage int
+		MaxPerPage     int
+	}
+
+	Filter struct {
+		Field    string
+		Operator Operator
+		// Values holds one value, or every value of the in operator
+		Values []string
+	}
+
+	Sort struct {
+		Field string
+		Desc  bool
+	}
+
+	// Spec describes one page of a list: filters are combined with AND,
+	// the page is chosen by page/per_page or by a cursor of the previous response
+	Spec struct {
+		Filters []Filter
+		Sort    []Sort
+		Mode    Mode
+		Page    int
+		PerPage int
+		Cursor  string
+		// SkipCount leaves out the COUNT(*) of the matching rows, the pagination then has no total
+		SkipCount bool
+	}
+
+	// Error reports the part of a query that the schema rejects
+	Error struct {
+		Field  string
+		Reason string
+	}
+)
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Reason)
+}
+
+// defaultOperators are allowed when a field does not list its operators
+var defaultOperators = map[FieldType][]Operator{
+	String: {OpEq, OpNe, OpLike, OpIn},
+	Int:    {OpEq, OpNe, OpGt, OpGte, OpLt, OpLte, OpIn},
+	Uint:   {OpEq, OpNe, OpGt, OpGte, OpLt, OpLte, OpIn},
+	Float:  {OpEq, OpNe, OpGt, OpGte, OpLt, OpLte},
+	Bool:   {OpEq, OpNe},
+	Time:   {OpEq, OpNe, OpGt, OpGte, OpLt, OpLte},
+}
+
+// Normalize checks the spec against the schema and fills the defaults of the page size, mode and sort.
+// The sort always ends with the key field so rows with equal sort values keep their order between pages.
+func (s Schema) Normalize(spec Spec) (Spec, error) {
+	for _, filter := range spec.Filters {
+		field, ok := s.Fields[filter.Field]
+		if !ok {
+			return spec, &Error{Field: filter.Field, Reason: "is not a filterable field"}
+		}
+		if !slices.Contains(field.operators(), filter.Operator) {
+			return spec, &Error{Field: filter.Field, Reason: fmt.Sprintf("does not support the %s operator", filter.Operator)}
+		}
+		if len(filter.Values) == 0 || (filter.Operator != OpIn && len(filter.Values) > 1) || len(filter.Values) > maxInValues {
+			return spec, &Error{Field: filter.Field, Reason: fmt.Sprintf("has a wrong number of values for the %s operator", filter.Operator)}
+		}
+		for _, value := range filter.Values {
+			if _, err := s.Value(filter.Field, value); err != nil {
+				return spec, err
+			}
+		}
+	}
+
+	sort := spec.Sort
+	if len(sort) == 0 {
+		sort = s.DefaultSort
+	}
+	key := s.key()
+	spec.Sort = make([]Sort, 0, len(sort)+1)
+	hasKey := false
+	for _, order := range sort {
+		if field, ok := s.Fields[order.Field]; !ok || !field.Sortable {
+			return spec, &Error{Field: order.Field, Reason: "is not a sortable field"}
+		}
+		spec.Sort = append(spec.Sort, order)
+		hasKey = hasKey || order.Field == key
+	}
+	if !hasKey {
+		spec.Sort = append(spec.Sort, Sort{Field: key})
+	}
+
+	if spec.Mode == "" {
+		spec.Mode = PageMode
+	}
+	if spec.Mode != PageMode && spec.Mode != CursorMode {
+		return spec, &Error{Field: "mode", Reason: "must be page or cursor"}
+	}
+	if spec.Page < 1 {
+		spec.Page = 1
+	}
+	if spec.PerPage < 1 {
+		spec.PerPage = s.defaultPerPage()
+	}
+	if spec.PerPage > s.maxPerPage() {
+		return spec, &Error{Field: "per_page", Reason: fmt.Sprintf("must be at most %d", s.maxPerPage())}
+	}
+
+	return spec, nil
+}
+
+// Column returns the database column of a field
+func (s Schema) Column(name string) string {
+	if field, ok := s.Fields[name]; ok && field.Column != "" {
+		return field.Column
+	}
+	return name
+}
+
+// Value converts a raw filter or cursor value to the type of the field
+func (s Schema) Value(name, raw string) (any, error) {
+	field, ok := s.Fields[name]
+	if !ok {
+		return nil, &Error{Field: name, Reason: "is not a known field"}
+	}
+
+	var (
+		value any
+		err   error
+	)
+	switch field.Type {
+	case String:
+		value = raw
+	case Int:
+		value, err = strconv.ParseInt(raw, 10, 64)
+	case Uint:
+		value, err = strconv.ParseUint(raw, 10, 64)
+	case Float:
+		value, err = strconv.ParseFloat(raw, 64)
+	case Bool:
+		value, err = strconv.ParseBool(raw)
+	case Time:
+		value, err = time.Parse(time.RFC3339Nano, raw)
+	}
+	if err != nil {
+		return nil, &Error{Field: name, Reason: fmt.Sprintf("has an invalid value %q", raw)}
+	}
+	return value, nil
+}
+
+// ParseSort reads a comma separated sort such as "-created_at,username", a leading minus sorts descending
+func ParseSort(raw string) []Sort {
+	var sort []Sort
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if name, desc := strings.CutPrefix(part, "-"); desc {
+			sort = append(sort, Sort{Field: name, Desc: true})
+		} else {
+			sort = append(sort, Sort{Field: strings.TrimPrefix(part, "+")})
+		}
+	}
+	return sort
+}
+
+// FormatSort is the inverse of ParseSort
+func FormatSort(sort []Sort) string {
+	parts := make([]string, 0, len(sort))
+	for _, order := range sort {
+		if order.Desc {
+			parts = append(parts, "-"+order.Field)
+		} else {
+			parts = append(parts, order.Field)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func (f Field) operators() []Operator {
+	if len(f.Operators) > 0 {
+		return f.Operators
+	}
+	return defaultOperators[f.Type]
+}
+
+func (s Schema) key() string {
+	if s.Key != "" {
+		return s.Key
+	}
+	return "id"
+}
+
+func (s Schema) defaultPerPage() int {
+	if s.DefaultPerPage > 0 {
+		return s.DefaultPerPage
+	}
+	return DefaultPerPage
+}
+
+func (s Schema) maxPerPage() int {
+	if s.MaxPerPage > 0 {
+		return s.MaxPerPage
+	}
+	return MaxPerPage
+}
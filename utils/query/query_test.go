@@ -0,0 +1,117 @@
+package query
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+var testSchema = Schema{
+	Fields: map[string]Field{
+		"id":         {Type: Uint, Sortable: true},
+		"name":       {Column: "username", Type: String, Sortable: true},
+		"salary":     {Type: Float, Sortable: true},
+		"created_at": {Type: Time},
+	},
+	DefaultSort: []Sort{{Field: "name"}},
+	MaxPerPage:  50,
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      Spec
+		wantField string
+		want      Spec
+	}{
+		{
+			name: "defaults and key appended to the default sort",
+			spec: Spec{},
+			want: Spec{Sort: []Sort{{Field: "name"}, {Field: "id"}}, Mode: PageMode, Page: 1, PerPage: DefaultPerPage},
+		},
+		{
+			name: "key already in the sort",
+			spec: Spec{Sort: []Sort{{Field: "id", Desc: true}}, Mode: CursorMode, PerPage: 5},
+			want: Spec{Sort: []Sort{{Field: "id", Desc: true}}, Mode: CursorMode, Page: 1, PerPage: 5},
+		},
+		{name: "unknown filter field", spec: Spec{Filters: []Filter{{Field: "password", Operator: OpEq, Values: []string{"x"}}}}, wantField: "password"},
+		{name: "operator not allowed for the type", spec: Spec{Filters: []Filter{{Field: "salary", Operator: OpLike, Values: []string{"1"}}}}, wantField: "salary"},
+		{name: "several values without in", spec: Spec{Filters: []Filter{{Field: "id", Operator: OpEq, Values: []string{"1", "2"}}}}, wantField: "id"},
+		{name: "value of the wrong type", spec: Spec{Filters: []Filter{{Field: "created_at", Operator: OpGt, Values: []string{"yesterday"}}}}, wantField: "created_at"},
+		{name: "field not sortable", spec: Spec{Sort: []Sort{{Field: "created_at"}}}, wantField: "created_at"},
+		{name: "page too large", spec: Spec{PerPage: 51}, wantField: "per_page"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testSchema.Normalize(tt.spec)
+			if tt.wantField != "" {
+				var queryErr *Error
+				if !errors.As(err, &queryErr) || queryErr.Field != tt.wantField {
+					t.Fatalf("Normalize() error = %v, want an error on %s", err, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Normalize() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Normalize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromValues(t *testing.T) {
+	values, _ := url.ParseQuery("filter[name]=alice&filter[id][in]=1,2&filter[salary][gte]=10&sort=-salary,name&page=2&per_page=20&skip_count=1&cursor=")
+	got, err := FromValues(values)
+	if err != nil {
+		t.Fatalf("FromValues() error = %v", err)
+	}
+
+	want := Spec{
+		Filters: []Filter{
+			{Field: "id", Operator: OpIn, Values: []string{"1", "2"}},
+			{Field: "name", Operator: OpEq, Values: []string{"alice"}},
+			{Field: "salary", Operator: OpGte, Values: []string{"10"}},
+		},
+		Sort:      []Sort{{Field: "salary", Desc: true}, {Field: "name"}},
+		Mode:      CursorMode,
+		Page:      2,
+		PerPage:   20,
+		SkipCount: true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromValues() = %+v, want %+v", got, want)
+	}
+
+	for _, raw := range []string{"filter[name=x", "filter[name][gte=x", "page=two", "per_page=-1", "skip_count=maybe"} {
+		values, _ := url.ParseQuery(raw)
+		if _, err := FromValues(values); err == nil {
+			t.Errorf("FromValues(%s) error = nil", raw)
+		}
+	}
+}
+
+func TestCursorCodec(t *testing.T) {
+	codec := NewCursorCodec("cursor-secret-with-at-least-32-bytes")
+	sort := []Sort{{Field: "salary", Desc: true}, {Field: "id"}}
+	cursor := codec.Encode(sort, Prev, []string{"10.5", "3"})
+
+	direction, values, err := codec.Decode(sort, cursor)
+	if err != nil || direction != Prev || !reflect.DeepEqual(values, []string{"10.5", "3"}) {
+		t.Fatalf("Decode() = %s, %v, %v", direction, values, err)
+	}
+
+	if _, _, err := codec.Decode([]Sort{{Field: "id"}}, cursor); err == nil {
+		t.Error("Decode() for another sort error = nil")
+	}
+	forged := NewCursorCodec("another-secret-with-at-least-32-bytes").Encode(sort, Next, []string{"0", "1"})
+	if _, _, err := codec.Decode(sort, forged); err == nil {
+		t.Error("Decode() of a cursor signed with another secret error = nil")
+	}
+	if _, _, err := codec.Decode(sort, "not a cursor"); err == nil {
+		t.Error("Decode() of garbage error = nil")
+	}
+}
@@ -0,0 +1,93 @@
+package query
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FromValues reads a spec from a query string:
+//
+//	?filter[username][like]=ali&filter[id][in]=1,2&sort=-created_at&page=2&per_page=20&skip_count=true
+//
+// filter[field]=value is short for the eq operator. The cursor parameter, even empty, selects cursor pagination.
+// The spec still has to be checked against a schema, FromValues only rejects what it cannot parse.
+func FromValues(values url.Values) (Spec, error) {
+	var spec Spec
+
+	// Sorted keys keep the filters, and so the SQL, in a stable order
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		rest, ok := strings.CutPrefix(key, "filter[")
+		if !ok {
+			continue
+		}
+		name, operator, err := parseFilterKey(key, rest)
+		if err != nil {
+			return spec, err
+		}
+		for _, value := range values[key] {
+			filter := Filter{Field: name, Operator: operator, Values: []string{value}}
+			if operator == OpIn {
+				filter.Values = strings.Split(value, ",")
+			}
+			spec.Filters = append(spec.Filters, filter)
+		}
+	}
+
+	spec.Sort = ParseSort(strings.Join(values["sort"], ","))
+
+	var err error
+	if spec.Page, err = intValue(values, "page"); err != nil {
+		return spec, err
+	}
+	if spec.PerPage, err = intValue(values, "per_page"); err != nil {
+		return spec, err
+	}
+	if raw := values.Get("skip_count"); raw != "" {
+		if spec.SkipCount, err = strconv.ParseBool(raw); err != nil {
+			return spec, &Error{Field: "skip_count", Reason: "must be true or false"}
+		}
+	}
+	if values.Has("cursor") {
+		spec.Mode = CursorMode
+		spec.Cursor = values.Get("cursor")
+	}
+
+	return spec, nil
+}
+
+// parseFilterKey splits "username][like]" into the field and the operator
+func parseFilterKey(key, rest string) (string, Operator, error) {
+	name, rest, ok := strings.Cut(rest, "]")
+	if !ok || name == "" {
+		return "", "", &Error{Field: key, Reason: "is not a filter[field] or filter[field][operator] parameter"}
+	}
+	if rest == "" {
+		return name, OpEq, nil
+	}
+
+	operator, ok := strings.CutPrefix(rest, "[")
+	if !ok || !strings.HasSuffix(operator, "]") || len(operator) < 2 {
+		return "", "", &Error{Field: key, Reason: "is not a filter[field] or filter[field][operator] parameter"}
+	}
+	return name, Operator(strings.TrimSuffix(operator, "]")), nil
+}
+
+func intValue(values url.Values, key string) (int, error) {
+	raw := values.Get(key)
+	if raw == "" {
+		return 0, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 0 {
+		return 0, &Error{Field: key, Reason: "must be a positive number"}
+	}
+	return value, nil
+}
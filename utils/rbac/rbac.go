@@ -0,0 +1,128 @@
+package rbac
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Wildcard grants every permission, "users:*" grants every permission of the users resource
+const Wildcard = "*"
+
+type (
+	// Rule is what a route or RPC requires: any of Roles and all of Permissions,
+	// an empty rule only requires an authenticated subject
+	Rule struct {
+		Roles       []string
+		Permissions []string
+	}
+
+	// Decision is the result of a policy check, Reason explains a denial for the logs
+	Decision struct {
+		Allowed bool
+		Reason  string
+	}
+
+	IPolicy interface {
+		// Authorize checks the roles of a subject against a rule
+		Authorize(roles []string, rule Rule) Decision
+		// Permissions lists the permissions granted to the roles
+		Permissions(roles []string) []string
+	}
+
+	Policy struct {
+		grants map[string][]string
+	}
+)
+
+// RequireRoles is satisfied by a subject with any of the roles
+func RequireRoles(roles ...string) Rule {
+	return Rule{Roles: roles}
+}
+
+// RequirePermissions is satisfied by a subject whose roles grant every permission
+func RequirePermissions(permissions ...string) Rule {
+	return Rule{Permissions: permissions}
+}
+
+// NewPolicy builds a policy from a role to permissions mapping, roles are matched case-insensitively
+func NewPolicy(rolePermissions map[string][]string) *Policy {
+	grants := make(map[string][]string, len(rolePermissions))
+	for role, permissions := range rolePermissions {
+		role = NormalizeRole(role)
+		for _, permission := range permissions {
+			if permission = strings.TrimSpace(permission); permission != "" {
+				grants[role] = append(grants[role], permission)
+			}
+		}
+	}
+
+	return &Policy{grants: grants}
+}
+
+func (p *Policy) Authorize(roles []string, rule Rule) Decision {
+	if len(rule.Roles) > 0 && !hasAnyRole(roles, rule.Roles) {
+		return Decision{Reason: fmt.Sprintf("requires one of the roles %s", strings.Join(rule.Roles, ", "))}
+	}
+
+	for _, required := range rule.Permissions {
+		if !p.granted(roles, required) {
+			return Decision{Reason: fmt.Sprintf("missing permission %s", required)}
+		}
+	}
+
+	return Decision{Allowed: true}
+}
+
+func (p *Policy) Permissions(roles []string) []string {
+	seen := make(map[string]bool)
+	var permissions []string
+	for _, role := range roles {
+		for _, permission := range p.grants[NormalizeRole(role)] {
+			if !seen[permission] {
+				seen[permission] = true
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	sort.Strings(permissions)
+	return permissions
+}
+
+func (p *Policy) granted(roles []string, required string) bool {
+	for _, role := range roles {
+		for _, permission := range p.grants[NormalizeRole(role)] {
+			if matches(permission, required) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matches compares a granted permission with a required one, "*" and "resource:*" are wildcards
+func matches(granted, required string) bool {
+	if granted == Wildcard || granted == required {
+		return true
+	}
+	if resource, ok := strings.CutSuffix(granted, ":"+Wildcard); ok {
+		return strings.HasPrefix(required, resource+":")
+	}
+	return false
+}
+
+func hasAnyRole(roles, accepted []string) bool {
+	for _, role := range roles {
+		for _, want := range accepted {
+			if NormalizeRole(role) == NormalizeRole(want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NormalizeRole is the form roles are compared in, trimmed and lower-case
+func NormalizeRole(role string) string {
+	return strings.ToLower(strings.TrimSpace(role))
+}
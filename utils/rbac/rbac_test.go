@@ -0,0 +1,56 @@
+package rbac
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPolicyAuthorize(t *testing.T) {
+	policy := NewPolicy(map[string][]string{
+		"admin":    {"*"},
+		"Employee": {"users:read", "reports:*"},
+	})
+
+	tests := []struct {
+		name   string
+		roles  []string
+		rule   Rule
+		want   bool
+		reason string
+	}{
+		{"empty rule", nil, Rule{}, true, ""},
+		{"wildcard grants everything", []string{"admin"}, RequirePermissions("users:delete"), true, ""},
+		{"exact permission", []string{"employee"}, RequirePermissions("users:read"), true, ""},
+		{"resource wildcard", []string{"employee"}, RequirePermissions("reports:export"), true, ""},
+		{"resource wildcard does not match prefix", []string{"employee"}, RequirePermissions("reportsx:read"), false, "missing permission reportsx:read"},
+		{"every permission is required", []string{"employee"}, RequirePermissions("users:read", "users:write"), false, "missing permission users:write"},
+		{"role is case-insensitive", []string{"ADMIN"}, RequireRoles("admin"), true, ""},
+		{"missing role", []string{"employee"}, RequireRoles("admin"), false, "requires one of the roles admin"},
+		{"unknown role has no permission", []string{"guest"}, RequirePermissions("users:read"), false, "missing permission users:read"},
+		{"role and permission", []string{"employee"}, Rule{Roles: []string{"employee"}, Permissions: []string{"users:read"}}, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := policy.Authorize(tt.roles, tt.rule)
+			if decision.Allowed != tt.want {
+				t.Fatalf("Authorize() allowed = %v, want %v (%s)", decision.Allowed, tt.want, decision.Reason)
+			}
+			if decision.Reason != tt.reason {
+				t.Errorf("Authorize() reason = %q, want %q", decision.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestPolicyPermissions(t *testing.T) {
+	policy := NewPolicy(map[string][]string{
+		"admin":    {"users:*"},
+		"employee": {"users:read", " reports:read "},
+	})
+
+	got := strings.Join(policy.Permissions([]string{"employee", "admin", "employee"}), ",")
+	if got != "reports:read,users:*,users:read" {
+		t.Errorf("Permissions() = %s", got)
+	}
+}
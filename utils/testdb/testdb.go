@@ -0,0 +1,52 @@
+// Package testdb opens the SQLite databases of the tests
+package testdb
+
+import (
+	"io/fs"
+	"testing"
+
+	"go.risoftinc.com/xarch/database/migration"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// Open returns an in-memory SQLite database closed with the test. It has one connection, because every
+// connection of :memory: is a database of its own, so a query outside the transaction of a context blocks.
+func Open(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	return db
+}
+
+// OpenSchema returns an Open database with the tables of the SQLite ddl migrations, without their seed data
+func OpenSchema(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	db := Open(t)
+	files, err := fs.Glob(migration.Files, "sqlite/ddl/*.up.sql")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("failed to find the sqlite migrations: %v", err)
+	}
+	for _, file := range files {
+		ddl, err := fs.ReadFile(migration.Files, file)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		if err := db.Exec(string(ddl)).Error; err != nil {
+			t.Fatalf("failed to apply %s: %v", file, err)
+		}
+	}
+
+	return db
+}
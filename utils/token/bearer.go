@@ -0,0 +1,37 @@
+package token
+
+import (
+	"errors"
+	"strings"
+
+	"go.risoftinc.com/xarch/constant"
+)
+
+// bearerPrefix is the scheme of the Authorization header and of the authorization gRPC metadata
+const bearerPrefix = "Bearer "
+
+// BearerToken returns the token of a "Bearer <token>" value, the scheme is case-insensitive
+func BearerToken(value string) string {
+	if len(value) < len(bearerPrefix) || !strings.EqualFold(value[:len(bearerPrefix)], bearerPrefix) {
+		return ""
+	}
+	return strings.TrimSpace(value[len(bearerPrefix):])
+}
+
+// ErrorKey maps a verification failure to its response message key
+func ErrorKey(err error) string {
+	switch {
+	case errors.Is(err, ErrMissing):
+		return constant.ErrorTokenMissing
+	case errors.Is(err, ErrExpired):
+		return constant.ErrorTokenExpired
+	case errors.Is(err, ErrNotYetValid):
+		return constant.ErrorTokenNotYetValid
+	case errors.Is(err, ErrRevoked):
+		return constant.ErrorTokenRevoked
+	case errors.Is(err, ErrNotConfigured):
+		return constant.ErrorUnauthorized
+	default:
+		return constant.ErrorTokenInvalid
+	}
+}
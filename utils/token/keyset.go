@@ -0,0 +1,220 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+// Supported signing algorithms
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+type (
+	// Key is a verification key of a key set, Signer is set when the JWK holds the private part
+	Key struct {
+		ID        string
+		Algorithm string
+		// Verify is the HMAC secret, *rsa.PublicKey or ed25519.PublicKey
+		Verify any
+		// Sign is the HMAC secret or a crypto.Signer, nil for public keys
+		Sign any
+	}
+
+	// KeySet holds the keys of a JWKS document indexed by key ID
+	KeySet struct {
+		keys map[string]Key
+	}
+
+	jwk struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Alg string `json:"alg"`
+		Use string `json:"use"`
+		Crv string `json:"crv"`
+		K   string `json:"k"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+		X   string `json:"x"`
+		D   string `json:"d"`
+		P   string `json:"p"`
+		Q   string `json:"q"`
+	}
+)
+
+// LoadKeySet reads a JWKS file ({"keys": [...]}) with oct (HS256), RSA (RS256) and OKP Ed25519 (EdDSA) keys
+func LoadKeySet(path string) (*KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	return ParseKeySet(data)
+}
+
+// ParseKeySet parses a JWKS document, every key needs a unique kid
+func ParseKeySet(data []byte) (*KeySet, error) {
+	var document struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("invalid JWKS document: %w", err)
+	}
+	if len(document.Keys) == 0 {
+		return nil, errors.New("JWKS document has no keys")
+	}
+
+	set := &KeySet{keys: make(map[string]Key, len(document.Keys))}
+	for i, raw := range document.Keys {
+		if raw.Use != "" && raw.Use != "sig" {
+			continue
+		}
+		if raw.Kid == "" {
+			return nil, fmt.Errorf("JWKS key %d has no kid", i)
+		}
+		if _, exists := set.keys[raw.Kid]; exists {
+			return nil, fmt.Errorf("JWKS key %q is duplicated", raw.Kid)
+		}
+
+		key, err := raw.key()
+		if err != nil {
+			return nil, fmt.Errorf("JWKS key %q: %w", raw.Kid, err)
+		}
+		set.keys[raw.Kid] = key
+	}
+
+	return set, nil
+}
+
+// NewSecretKeySet returns a key set with a single HS256 secret that is used when tokens have no kid
+func NewSecretKeySet(secret string) *KeySet {
+	return &KeySet{keys: map[string]Key{
+		"": {Algorithm: AlgHS256, Verify: []byte(secret), Sign: []byte(secret)},
+	}}
+}
+
+// Key returns the key with the given ID, a set with a single key also answers for tokens without kid
+func (s *KeySet) Key(id string) (Key, bool) {
+	if key, ok := s.keys[id]; ok {
+		return key, true
+	}
+	if id == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	return Key{}, false
+}
+
+// Algorithms returns every algorithm used by the keys of the set
+func (s *KeySet) Algorithms() []string {
+	seen := make(map[string]bool)
+	var algorithms []string
+	for _, key := range s.keys {
+		if !seen[key.Algorithm] {
+			seen[key.Algorithm] = true
+			algorithms = append(algorithms, key.Algorithm)
+		}
+	}
+	return algorithms
+}
+
+func (k jwk) key() (Key, error) {
+	key := Key{ID: k.Kid}
+
+	switch k.Kty {
+	case "oct":
+		secret, err := decodeSegment(k.K)
+		if err != nil || len(secret) < 32 {
+			return Key{}, errors.New("oct keys need a base64url k of at least 32 bytes")
+		}
+		key.Algorithm, key.Verify, key.Sign = AlgHS256, secret, secret
+
+	case "RSA":
+		public, err := rsaPublicKey(k.N, k.E)
+		if err != nil {
+			return Key{}, err
+		}
+		key.Algorithm, key.Verify = AlgRS256, public
+		if k.D != "" {
+			private, err := rsaPrivateKey(public, k.D, k.P, k.Q)
+			if err != nil {
+				return Key{}, err
+			}
+			key.Sign = crypto.Signer(private)
+		}
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return Key{}, fmt.Errorf("unsupported OKP curve %q", k.Crv)
+		}
+		public, err := decodeSegment(k.X)
+		if err != nil || len(public) != ed25519.PublicKeySize {
+			return Key{}, errors.New("invalid Ed25519 public key")
+		}
+		key.Algorithm, key.Verify = AlgEdDSA, ed25519.PublicKey(public)
+		if k.D != "" {
+			seed, err := decodeSegment(k.D)
+			if err != nil || len(seed) != ed25519.SeedSize {
+				return Key{}, errors.New("invalid Ed25519 private key")
+			}
+			key.Sign = crypto.Signer(ed25519.NewKeyFromSeed(seed))
+		}
+
+	default:
+		return Key{}, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+
+	if k.Alg != "" && k.Alg != key.Algorithm {
+		return Key{}, fmt.Errorf("alg %q does not match key type %q", k.Alg, k.Kty)
+	}
+
+	return key, nil
+}
+
+func rsaPublicKey(n, e string) (*rsa.PublicKey, error) {
+	modulus, err := decodeSegment(n)
+	if err != nil || len(modulus) < 256 {
+		return nil, errors.New("RSA keys need a modulus of at least 2048 bits")
+	}
+	exponent, err := decodeSegment(e)
+	if err != nil || len(exponent) == 0 || len(exponent) > 4 {
+		return nil, errors.New("invalid RSA exponent")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulus),
+		E: int(new(big.Int).SetBytes(exponent).Int64()),
+	}, nil
+}
+
+func rsaPrivateKey(public *rsa.PublicKey, d, p, q string) (*rsa.PrivateKey, error) {
+	values := make([]*big.Int, 0, 3)
+	for _, segment := range []string{d, p, q} {
+		raw, err := decodeSegment(segment)
+		if err != nil || len(raw) == 0 {
+			return nil, errors.New("RSA private keys need d, p and q")
+		}
+		values = append(values, new(big.Int).SetBytes(raw))
+	}
+
+	private := &rsa.PrivateKey{PublicKey: *public, D: values[0], Primes: values[1:]}
+	if err := private.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid RSA private key: %w", err)
+	}
+	private.Precompute()
+
+	return private, nil
+}
+
+func decodeSegment(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(value)
+}
@@ -0,0 +1,47 @@
+package token
+
+import (
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// signingMethods maps the key algorithms to their JWT signing method
+var signingMethods = map[string]jwt.SigningMethod{
+	AlgHS256: jwt.SigningMethodHS256,
+	AlgRS256: jwt.SigningMethodRS256,
+	AlgEdDSA: jwt.SigningMethodEdDSA,
+}
+
+// Sign signs the claims with the key selected by Options.SigningKeyID.
+// Keys are read from the current key set, so a rotated signing key is used once the JWKS file is reloaded.
+func (v *Verifier) Sign(claims *Claims) (string, error) {
+	v.maybeReload()
+
+	v.mu.RLock()
+	keys := v.keys
+	v.mu.RUnlock()
+	if keys == nil {
+		return "", ErrNotConfigured
+	}
+
+	key, ok := keys.Key(v.opts.SigningKeyID)
+	if !ok {
+		return "", fmt.Errorf("%w: unknown signing key ID %q", ErrNotConfigured, v.opts.SigningKeyID)
+	}
+	if key.Sign == nil {
+		return "", fmt.Errorf("%w: key %q has no private part", ErrNotConfigured, key.ID)
+	}
+
+	t := jwt.NewWithClaims(signingMethods[key.Algorithm], claims)
+	if key.ID != "" {
+		t.Header["kid"] = key.ID
+	}
+
+	raw, err := t.SignedString(key.Sign)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return raw, nil
+}
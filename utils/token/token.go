@@ -0,0 +1,199 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Verification failures, every other failure wraps ErrInvalid
+var (
+	ErrMissing       = errors.New("token is missing")
+	ErrInvalid       = errors.New("token is invalid")
+	ErrExpired       = errors.New("token has expired")
+	ErrNotYetValid   = errors.New("token is not valid yet")
+	ErrRevoked       = errors.New("token has been revoked")
+	ErrNotConfigured = errors.New("token verification is not configured")
+)
+
+// claimsKey stores the verified claims in the request context
+type claimsKey struct{}
+
+type (
+	// Options describes the keys and the registered claims accepted by the verifier
+	Options struct {
+		// Secret is a HS256 secret used when JWKSFile is empty
+		Secret   string
+		JWKSFile string
+		// ReloadInterval is the minimum time between two checks of the JWKS file, zero disables reloading
+		ReloadInterval time.Duration
+		Issuer         string
+		Audience       string
+		ClockSkew      time.Duration
+		// SigningKeyID selects the key that signs issued tokens, empty uses the secret or the only key of the set
+		SigningKeyID string
+		// OnReload is called after every reload attempt of the JWKS file with its result
+		OnReload func(err error)
+	}
+
+	// Claims are the registered claims plus the user attributes carried by access tokens
+	Claims struct {
+		jwt.RegisteredClaims
+		Username string   `json:"username,omitempty"`
+		Roles    []string `json:"roles,omitempty"`
+	}
+
+	IVerifier interface {
+		// Verify checks the signature, algorithm, issuer, audience and validity window of a raw token
+		Verify(raw string) (*Claims, error)
+	}
+
+	ISigner interface {
+		// Sign signs the claims with the signing key, its kid is written in the token header
+		Sign(claims *Claims) (string, error)
+	}
+
+	// Verifier checks tokens against a key set that is reloaded when the JWKS file changes,
+	// so signing keys can be rotated by adding the new kid before it is used.
+	// It also signs tokens when the key set holds the private part of the signing key.
+	Verifier struct {
+		opts Options
+
+		mu        sync.RWMutex
+		keys      *KeySet
+		modTime   time.Time
+		lastCheck time.Time
+	}
+)
+
+// NewVerifier loads the key set once and fails when the JWKS file is missing or invalid.
+// Without secret and JWKS file every token is rejected with ErrNotConfigured.
+func NewVerifier(opts Options) (*Verifier, error) {
+	v := &Verifier{opts: opts}
+
+	switch {
+	case opts.JWKSFile != "":
+		if err := v.reload(); err != nil {
+			return nil, err
+		}
+	case opts.Secret != "":
+		v.keys = NewSecretKeySet(opts.Secret)
+	}
+
+	return v, nil
+}
+
+func (v *Verifier) Verify(raw string) (*Claims, error) {
+	if raw == "" {
+		return nil, ErrMissing
+	}
+
+	v.maybeReload()
+
+	v.mu.RLock()
+	keys := v.keys
+	v.mu.RUnlock()
+	if keys == nil {
+		return nil, ErrNotConfigured
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods(keys.Algorithms()),
+		jwt.WithLeeway(v.opts.ClockSkew),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	}
+	if v.opts.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(v.opts.Issuer))
+	}
+	if v.opts.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(v.opts.Audience))
+	}
+
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := keys.Key(kid)
+		if !ok {
+			return nil, fmt.Errorf("unknown key ID %q", kid)
+		}
+		// The algorithm is bound to the key, a RS256 public key is never used as a HS256 secret
+		if t.Method.Alg() != key.Algorithm {
+			return nil, fmt.Errorf("algorithm %s does not match key %q", t.Method.Alg(), kid)
+		}
+		return key.Verify, nil
+	}, parserOpts...)
+
+	switch {
+	case err == nil:
+		return claims, nil
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return nil, ErrExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return nil, ErrNotYetValid
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+}
+
+// maybeReload reloads the JWKS file when the reload interval has passed and the file changed.
+// A failed reload keeps the previous keys.
+func (v *Verifier) maybeReload() {
+	if v.opts.JWKSFile == "" || v.opts.ReloadInterval <= 0 {
+		return
+	}
+
+	v.mu.Lock()
+	if time.Since(v.lastCheck) < v.opts.ReloadInterval {
+		v.mu.Unlock()
+		return
+	}
+	v.lastCheck = time.Now()
+	info, err := os.Stat(v.opts.JWKSFile)
+	changed := err != nil || !info.ModTime().Equal(v.modTime)
+	v.mu.Unlock()
+
+	if !changed {
+		return
+	}
+
+	err = v.reload()
+	if v.opts.OnReload != nil {
+		v.opts.OnReload(err)
+	}
+}
+
+func (v *Verifier) reload() error {
+	info, err := os.Stat(v.opts.JWKSFile)
+	if err != nil {
+		return fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+
+	keys, err := LoadKeySet(v.opts.JWKSFile)
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.keys = keys
+	v.modTime = info.ModTime()
+
+	return nil
+}
+
+// WithClaims stores the verified claims in the context
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext returns the verified claims of the caller
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok
+}
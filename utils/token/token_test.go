@@ -0,0 +1,369 @@
+package token
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func writeJWKS(t *testing.T, path string, keys ...map[string]string) {
+	t.Helper()
+	data, _ := json.Marshal(map[string]any{"keys": keys})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write JWKS: %v", err)
+	}
+}
+
+func rsaJWK(kid string, key *rsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"n":   b64(key.N.Bytes()),
+		"e":   b64(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ed25519JWK(kid string, key ed25519.PublicKey) map[string]string {
+	return map[string]string{"kty": "OKP", "crv": "Ed25519", "kid": kid, "x": b64(key)}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key any, claims Claims) string {
+	t.Helper()
+	tok := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		tok.Header["kid"] = kid
+	}
+	raw, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return raw
+}
+
+func validClaims() Claims {
+	now := time.Now()
+	return Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "1",
+			Issuer:    "xarch",
+			Audience:  jwt.ClaimStrings{"xarch"},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		},
+		Username: "jane",
+		Roles:    []string{"admin"},
+	}
+}
+
+func TestVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	edPublic, edPrivate, _ := ed25519.GenerateKey(rand.Reader)
+
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, jwksFile, rsaJWK("rsa-1", rsaKey), ed25519JWK("ed-1", edPublic))
+
+	secretVerifier, _ := NewVerifier(Options{Secret: testSecret, Issuer: "xarch", Audience: "xarch", ClockSkew: 30 * time.Second})
+	jwksVerifier, err := NewVerifier(Options{JWKSFile: jwksFile, Issuer: "xarch", Audience: "xarch"})
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+	unconfigured, _ := NewVerifier(Options{})
+
+	with := func(modify func(c *Claims)) Claims {
+		claims := validClaims()
+		modify(&claims)
+		return claims
+	}
+
+	tests := []struct {
+		name     string
+		verifier IVerifier
+		token    string
+		wantErr  error
+	}{
+		{
+			name:     "HS256 secret",
+			verifier: secretVerifier,
+			token:    sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), validClaims()),
+		},
+		{
+			name:     "RS256 key from JWKS",
+			verifier: jwksVerifier,
+			token:    sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, validClaims()),
+		},
+		{
+			name:     "EdDSA key from JWKS",
+			verifier: jwksVerifier,
+			token:    sign(t, jwt.SigningMethodEdDSA, "ed-1", edPrivate, validClaims()),
+		},
+		{
+			name:     "missing",
+			verifier: secretVerifier,
+			wantErr:  ErrMissing,
+		},
+		{
+			name:     "expired",
+			verifier: secretVerifier,
+			token: sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), with(func(c *Claims) {
+				c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+			})),
+			wantErr: ErrExpired,
+		},
+		{
+			name:     "expired within clock skew",
+			verifier: secretVerifier,
+			token: sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), with(func(c *Claims) {
+				c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-10 * time.Second))
+			})),
+		},
+		{
+			name:     "not valid yet",
+			verifier: secretVerifier,
+			token: sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), with(func(c *Claims) {
+				c.NotBefore = jwt.NewNumericDate(time.Now().Add(time.Hour))
+			})),
+			wantErr: ErrNotYetValid,
+		},
+		{
+			name:     "without expiry",
+			verifier: secretVerifier,
+			token: sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), with(func(c *Claims) {
+				c.ExpiresAt = nil
+			})),
+			wantErr: ErrInvalid,
+		},
+		{
+			name:     "wrong issuer",
+			verifier: secretVerifier,
+			token: sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), with(func(c *Claims) {
+				c.Issuer = "someone-else"
+			})),
+			wantErr: ErrInvalid,
+		},
+		{
+			name:     "wrong audience",
+			verifier: secretVerifier,
+			token: sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), with(func(c *Claims) {
+				c.Audience = jwt.ClaimStrings{"billing"}
+			})),
+			wantErr: ErrInvalid,
+		},
+		{
+			name:     "wrong secret",
+			verifier: secretVerifier,
+			token:    sign(t, jwt.SigningMethodHS256, "", []byte("another-secret-another-secret-xx"), validClaims()),
+			wantErr:  ErrInvalid,
+		},
+		{
+			name:     "unknown key ID",
+			verifier: jwksVerifier,
+			token:    sign(t, jwt.SigningMethodRS256, "rsa-2", rsaKey, validClaims()),
+			wantErr:  ErrInvalid,
+		},
+		{
+			name:     "algorithm does not match the key",
+			verifier: jwksVerifier,
+			token:    sign(t, jwt.SigningMethodEdDSA, "rsa-1", edPrivate, validClaims()),
+			wantErr:  ErrInvalid,
+		},
+		{
+			name:     "unsigned",
+			verifier: secretVerifier,
+			token:    sign(t, jwt.SigningMethodNone, "", jwt.UnsafeAllowNoneSignatureType, validClaims()),
+			wantErr:  ErrInvalid,
+		},
+		{
+			name:     "not configured",
+			verifier: unconfigured,
+			token:    sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), validClaims()),
+			wantErr:  ErrNotConfigured,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := tt.verifier.Verify(tt.token)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if claims.Subject != "1" || claims.Username != "jane" || len(claims.Roles) != 1 {
+				t.Errorf("Verify() claims = %+v", claims)
+			}
+		})
+	}
+}
+
+func TestVerifierReloadsRotatedKeys(t *testing.T) {
+	oldKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	newKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, jwksFile, rsaJWK("2024", oldKey))
+
+	verifier, err := NewVerifier(Options{JWKSFile: jwksFile, ReloadInterval: time.Nanosecond})
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+
+	newToken := sign(t, jwt.SigningMethodRS256, "2025", newKey, validClaims())
+	if _, err := verifier.Verify(newToken); err == nil {
+		t.Fatal("Verify() accepted a key that is not published yet")
+	}
+
+	// Publish the new key next to the old one, tokens of both keys are accepted
+	writeJWKS(t, jwksFile, rsaJWK("2024", oldKey), rsaJWK("2025", newKey))
+	future := time.Now().Add(time.Second)
+	_ = os.Chtimes(jwksFile, future, future)
+
+	for _, raw := range []string{newToken, sign(t, jwt.SigningMethodRS256, "2024", oldKey, validClaims())} {
+		if _, err := verifier.Verify(raw); err != nil {
+			t.Errorf("Verify() after rotation error = %v", err)
+		}
+	}
+}
+
+func TestParseKeySetPrivateKeys(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	edPublic, edPrivate, _ := ed25519.GenerateKey(rand.Reader)
+
+	rsaPrivate := rsaJWK("rsa", rsaKey)
+	rsaPrivate["d"] = b64(rsaKey.D.Bytes())
+	rsaPrivate["p"] = b64(rsaKey.Primes[0].Bytes())
+	rsaPrivate["q"] = b64(rsaKey.Primes[1].Bytes())
+
+	edKey := ed25519JWK("ed", edPublic)
+	edKey["d"] = b64(edPrivate.Seed())
+
+	data, _ := json.Marshal(map[string]any{"keys": []map[string]string{
+		rsaPrivate,
+		edKey,
+		{"kty": "oct", "kid": "hmac", "k": b64([]byte(testSecret))},
+	}})
+	set, err := ParseKeySet(data)
+	if err != nil {
+		t.Fatalf("ParseKeySet() error = %v", err)
+	}
+
+	for kid, alg := range map[string]string{"rsa": AlgRS256, "ed": AlgEdDSA, "hmac": AlgHS256} {
+		key, ok := set.Key(kid)
+		if !ok || key.Algorithm != alg || key.Sign == nil {
+			t.Errorf("Key(%q) = %+v, want a %s signing key", kid, key, alg)
+		}
+	}
+	if _, ok := set.Key(""); ok {
+		t.Error("Key(\"\") should not pick a key from a set with several keys")
+	}
+	if signer, ok := mustKey(set, "rsa").Sign.(crypto.Signer); !ok || !signer.Public().(*rsa.PublicKey).Equal(&rsaKey.PublicKey) {
+		t.Error("RSA signer does not match the public key")
+	}
+}
+
+func mustKey(set *KeySet, kid string) Key {
+	key, _ := set.Key(kid)
+	return key
+}
+
+func TestClaimsContext(t *testing.T) {
+	if _, ok := ClaimsFromContext(context.Background()); ok {
+		t.Error("ClaimsFromContext() without claims ok = true")
+	}
+
+	claims := validClaims()
+	got, ok := ClaimsFromContext(WithClaims(context.Background(), &claims))
+	if !ok || got.Username != "jane" {
+		t.Errorf("ClaimsFromContext() = %+v, %v", got, ok)
+	}
+}
+
+func TestSignRoundTrip(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	rsaPrivate := rsaJWK("rsa-1", rsaKey)
+	rsaPrivate["d"] = b64(rsaKey.D.Bytes())
+	rsaPrivate["p"] = b64(rsaKey.Primes[0].Bytes())
+	rsaPrivate["q"] = b64(rsaKey.Primes[1].Bytes())
+
+	edPublic, _, _ := ed25519.GenerateKey(rand.Reader)
+
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, jwksFile, rsaPrivate, ed25519JWK("ed-1", edPublic))
+
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr error
+	}{
+		{name: "HS256 secret", opts: Options{Secret: testSecret}},
+		{name: "RS256 key from JWKS", opts: Options{JWKSFile: jwksFile, SigningKeyID: "rsa-1"}},
+		{name: "public key only", opts: Options{JWKSFile: jwksFile, SigningKeyID: "ed-1"}, wantErr: ErrNotConfigured},
+		{name: "unknown key ID", opts: Options{JWKSFile: jwksFile, SigningKeyID: "rsa-2"}, wantErr: ErrNotConfigured},
+		{name: "not configured", opts: Options{}, wantErr: ErrNotConfigured},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier, err := NewVerifier(tt.opts)
+			if err != nil {
+				t.Fatalf("NewVerifier() error = %v", err)
+			}
+
+			claims := validClaims()
+			raw, err := verifier.Sign(&claims)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Sign() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+
+			got, err := verifier.Verify(raw)
+			if err != nil || got.Username != "jane" {
+				t.Errorf("Verify() of a signed token = %+v, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	tests := map[string]string{
+		"Bearer abc":   "abc",
+		"bearer  abc ": "abc",
+		"Basic abc":    "",
+		"Bearer":       "",
+		"":             "",
+	}
+	for value, want := range tests {
+		if got := BearerToken(value); got != want {
+			t.Errorf("BearerToken(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
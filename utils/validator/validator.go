@@ -87,3 +87,12 @@ func (ve *ValidationErrors) Error() string {
 func (ve *ValidationErrors) GetValidationErrors() []ValidationError {
 	return ve.Errors
 }
+
+// Fields returns the names of the invalid fields
+func (ve *ValidationErrors) Fields() []string {
+	fields := make([]string, 0, len(ve.Errors))
+	for _, err := range ve.Errors {
+		fields = append(fields, err.Field)
+	}
+	return fields
+}